@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aiomayo/hdf/internal/config"
+	"github.com/aiomayo/hdf/internal/process"
 	"github.com/aiomayo/hdf/internal/ui"
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
@@ -98,7 +99,7 @@ func formatShow(cfg *config.Config) string {
 			val, _ := config.GetValue(cfg, f.Key)
 			formatted := config.FormatValue(&f, val)
 
-			if f.Kind == config.StringMap {
+			if f.Kind == config.StringMap || f.Kind == config.AliasMap {
 				fmt.Fprintf(&b, "%s\n", formatted)
 			} else {
 				fmt.Fprintf(&b, "%-20s = %-10s  # %s\n", f.DisplayName(), formatted, f.Desc)
@@ -241,7 +242,7 @@ func newConfigSetCmd() *cobra.Command {
 				return fmt.Errorf("unknown config key: %s", key)
 			}
 
-			if f.Kind == config.StringMap || f.Kind == config.StringSlice {
+			if f.Kind == config.StringMap || f.Kind == config.AliasMap || f.Kind == config.StringSlice {
 				return fmt.Errorf("%q is a collection type, use a dedicated subcommand to manage it", key)
 			}
 
@@ -266,11 +267,12 @@ func newConfigSetCmd() *cobra.Command {
 
 func newConfigAliasCmd() *cobra.Command {
 	var del bool
+	var alias config.Alias
 
 	cmd := &cobra.Command{
-		Use:   "alias <name> [value]",
+		Use:   "alias <name> [query...]",
 		Short: "Add or remove an alias",
-		Args:  cobra.RangeArgs(1, 2),
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -292,19 +294,32 @@ func newConfigAliasCmd() *cobra.Command {
 			}
 
 			if len(args) < 2 {
-				return fmt.Errorf("usage: hdf config alias <name> <value>")
+				return fmt.Errorf("usage: hdf config alias <name> <query...>")
+			}
+			if alias.Signal != "" {
+				if alias.Force {
+					return fmt.Errorf("--signal cannot be combined with --force")
+				}
+				if _, err := process.ParseSignal(alias.Signal); err != nil {
+					return err
+				}
 			}
 
-			cfg.Aliases[name] = args[1]
+			alias.Queries = args[1:]
+			cfg.Aliases[name] = alias
 			if err := config.Save(cfg); err != nil {
 				return err
 			}
-			fmt.Printf("alias %s = %q\n", name, args[1])
+			fmt.Printf("alias %s = %s\n", name, alias)
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&del, "delete", false, "remove an alias")
+	cmd.Flags().BoolVarP(&alias.Force, "force", "f", false, "always force kill (SIGKILL) this alias")
+	cmd.Flags().BoolVarP(&alias.Tree, "tree", "t", false, "always kill the process tree of this alias")
+	cmd.Flags().StringVarP(&alias.Signal, "signal", "s", "", "signal to send to this alias (e.g. HUP)")
+	cmd.Flags().DurationVar(&alias.Timeout, "timeout", 0, "graceful shutdown timeout for this alias")
 	return cmd
 }
 
@@ -368,7 +383,7 @@ func completeConfigKeys(_ *cobra.Command, args []string, _ string) ([]string, co
 	}
 	var keys []string
 	for _, f := range config.Schema {
-		if f.Kind != config.StringMap && f.Kind != config.StringSlice {
+		if f.Kind != config.StringMap && f.Kind != config.AliasMap && f.Kind != config.StringSlice {
 			keys = append(keys, f.Key)
 		}
 	}
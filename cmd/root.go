@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/aiomayo/hdf/internal/config"
 	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/filter"
 	"github.com/aiomayo/hdf/internal/finder"
+	"github.com/aiomayo/hdf/internal/fuzzy"
 	"github.com/aiomayo/hdf/internal/killer"
 	"github.com/aiomayo/hdf/internal/process"
 	"github.com/aiomayo/hdf/internal/ui"
@@ -33,6 +37,11 @@ func SetVersionInfo(version, commit, date string) {
 	}
 }
 
+const (
+	suggestThreshold = 0.5
+	maxSuggestions   = 3
+)
+
 type exitError struct {
 	code    int
 	message string
@@ -41,18 +50,48 @@ type exitError struct {
 func (e *exitError) Error() string { return e.message }
 
 type flags struct {
-	port       uint32
-	name       string
-	pid        int32
+	ports      []string
+	remotes    []string
+	services   []string
+	sockState  string
+	names      []string
+	regexes    []string
+	regexField string
+	files      []string
+	mounts     []string
+	cwds       []string
+	envs       []string
+	parents    []string
+	ancestors  []string
+	ttys       []string
+	sessions   []int32
+	pgids      []int32
+	here       bool
+	pids       []int32
 	user       string
+	where      string
+	olderThan  string
+	newerThan  string
+	cpuAbove   string
+	memAbove   string
+	memTree    bool
+	sample     time.Duration
+	sortBy     string
+	states     []string
+	reapParent bool
+	fuzzy      bool
+	excludes   []string
 	force      bool
 	all        bool
 	yes        bool
 	dryRun     bool
 	graceful   bool
 	timeout    string
+	signal     string
 	tree       bool
+	stopUnit   bool
 	list       bool
+	columns    []string
 	verbose    bool
 	quiet      bool
 	interact   bool
@@ -93,11 +132,11 @@ func newRootCmd() *cobra.Command {
 	f := &flags{}
 
 	cmd := &cobra.Command{
-		Use:     "hdf [query]",
+		Use:     "hdf [query...]",
 		Short:   "Kill processes by port, name, PID, or pattern",
-		Long:    fmt.Sprintf("hdf — a smart process killer. Pass a port number, process name, PID, or glob pattern.\n\nConfig: %s", config.Path()),
+		Long:    fmt.Sprintf("hdf — a smart process killer. Pass one or more port numbers, process names, PIDs, or glob patterns.\n\nConfig: %s", config.Path()),
 		Version: versionString,
-		Args:    cobra.MaximumNArgs(1),
+		Args:    cobra.ArbitraryArgs,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			v, _ := cmd.Flags().GetBool("verbose")
 			q, _ := cmd.Flags().GetBool("quiet")
@@ -125,18 +164,48 @@ func newRootCmd() *cobra.Command {
 	cmd.CompletionOptions.DisableDefaultCmd = true
 	cmd.AddCommand(newConfigCmd())
 
-	cmd.Flags().Uint32VarP(&f.port, "port", "p", 0, "kill by port number")
-	cmd.Flags().StringVarP(&f.name, "name", "n", "", "kill by process name")
-	cmd.Flags().Int32Var(&f.pid, "pid", 0, "kill by PID")
+	cmd.Flags().StringArrayVarP(&f.ports, "port", "p", nil, "kill by port number or range (e.g. 3000-3010), repeatable")
+	cmd.Flags().StringArrayVar(&f.services, "service", nil, "kill by service name resolved to a port (e.g. postgres, http-alt), repeatable")
+	cmd.Flags().StringArrayVar(&f.remotes, "remote", nil, "kill processes connected to a remote port or host:port, repeatable")
+	cmd.Flags().StringVar(&f.sockState, "socket-state", "", "socket state for port and remote queries (e.g. ESTABLISHED, TIME_WAIT, any)")
+	cmd.Flags().StringArrayVarP(&f.names, "name", "n", nil, "kill by process name, repeatable")
+	cmd.Flags().BoolVar(&f.fuzzy, "fuzzy", false, "match names approximately and rank matches by similarity")
+	cmd.Flags().StringArrayVar(&f.regexes, "regex", nil, "kill by regular expression, repeatable")
+	cmd.Flags().StringVar(&f.regexField, "regex-field", "", "field matched by --regex (name|cmdline|exe, default name or cmdline)")
+	cmd.Flags().StringArrayVar(&f.files, "file", nil, "kill processes holding a file or directory open, repeatable")
+	cmd.Flags().StringArrayVar(&f.mounts, "mount", nil, "kill processes using any file on the filesystem containing a path, repeatable")
+	cmd.Flags().StringArrayVar(&f.cwds, "cwd", nil, "kill processes whose working directory is inside a path, repeatable")
+	cmd.Flags().BoolVar(&f.here, "here", false, "kill processes running from the current directory or its git repository")
+	cmd.Flags().StringArrayVar(&f.envs, "env", nil, "kill by environment variable (KEY or KEY=VALUE, globs allowed), repeatable")
+	cmd.Flags().StringArrayVar(&f.parents, "parent", nil, "kill direct children of a PID or query (the parent is kept), repeatable")
+	cmd.Flags().StringArrayVar(&f.ancestors, "descendants-of", nil, "kill all descendants of a PID or query (the ancestor is kept), repeatable")
+	cmd.Flags().StringArrayVar(&f.ttys, "tty", nil, "kill processes attached to a terminal (e.g. pts/3), repeatable")
+	cmd.Flags().Int32SliceVar(&f.sessions, "session", nil, "kill processes in a session ID, repeatable")
+	cmd.Flags().Int32SliceVar(&f.pgids, "pgid", nil, "kill processes in a process group ID, repeatable")
+	cmd.Flags().Int32SliceVar(&f.pids, "pid", nil, "kill by PID, repeatable")
+	cmd.Flags().StringArrayVarP(&f.excludes, "exclude", "x", nil, "skip processes matching a query (same syntax as positional queries), repeatable")
 	cmd.Flags().StringVarP(&f.user, "user", "u", "", "filter by user")
+	cmd.Flags().StringVarP(&f.where, "where", "w", "", `filter by expression (e.g. 'name~"node*" && port>=3000 && rss>500M')`)
+	cmd.Flags().StringVar(&f.olderThan, "older-than", "", "only processes running longer than a duration (e.g. 6h, 2d)")
+	cmd.Flags().StringVar(&f.newerThan, "newer-than", "", "only processes started within a duration (e.g. 10m)")
+	cmd.Flags().StringVar(&f.cpuAbove, "cpu-above", "", "only processes using more than a CPU percentage (e.g. 80)")
+	cmd.Flags().StringVar(&f.memAbove, "mem-above", "", "only processes using more memory than a size or share of RAM (e.g. 2G, 10%)")
+	cmd.Flags().BoolVar(&f.memTree, "mem-tree", false, "compare --mem-above against the RSS of each process and all its descendants")
+	cmd.Flags().StringSliceVar(&f.states, "state", nil, "only processes in a state (R, S, D, Z, T, I or running, sleeping, disk, zombie, stopped, idle)")
+	cmd.Flags().DurationVar(&f.sample, "sample", 0, "measure current CPU usage over an interval (e.g. 500ms) instead of the lifetime average")
+	cmd.Flags().StringVar(&f.sortBy, "sort", "", "sort matches by "+strings.Join(filter.SortKeys(), "|"))
 	cmd.Flags().BoolVarP(&f.force, "force", "f", false, "force kill (SIGKILL)")
 	cmd.Flags().BoolVarP(&f.all, "all", "a", false, "kill all matching processes")
 	cmd.Flags().BoolVarP(&f.yes, "yes", "y", false, "skip confirmation")
 	cmd.Flags().BoolVarP(&f.dryRun, "dry-run", "d", false, "show what would be killed")
 	cmd.Flags().BoolVarP(&f.graceful, "graceful", "g", false, "graceful shutdown (SIGTERM then SIGKILL)")
-	cmd.Flags().StringVar(&f.timeout, "timeout", "5s", "graceful shutdown timeout")
+	cmd.Flags().StringVar(&f.timeout, "timeout", "", "graceful shutdown timeout (default graceful_timeout from config)")
+	cmd.Flags().StringVarP(&f.signal, "signal", "s", "", "send a specific signal instead of killing (e.g. HUP, USR1)")
 	cmd.Flags().BoolVarP(&f.tree, "tree", "t", false, "kill process tree")
+	cmd.Flags().BoolVar(&f.reapParent, "reap-parent", false, "kill the parent of a zombie process so it can be reaped")
+	cmd.Flags().BoolVar(&f.stopUnit, "stop-unit", false, "stop the owning systemd unit instead of signaling")
 	cmd.Flags().BoolVarP(&f.list, "list", "l", false, "list matching processes without killing")
+	cmd.Flags().StringSliceVar(&f.columns, "columns", nil, "extra table columns ("+strings.Join(ui.Columns(), ",")+")")
 	cmd.Flags().BoolVarP(&f.interact, "interactive", "i", false, "interactive process selection")
 	cmd.Flags().StringVarP(&f.completion, "completion", "c", "", "generate completion script (bash|zsh|fish|powershell)")
 
@@ -147,7 +216,7 @@ func newRootCmd() *cobra.Command {
 }
 
 func hasQueryFlags(f *flags) bool {
-	return f.port > 0 || f.name != "" || f.pid > 0 || f.user != ""
+	return len(f.ports) > 0 || len(f.remotes) > 0 || len(f.services) > 0 || len(f.names) > 0 || len(f.regexes) > 0 || len(f.files) > 0 || len(f.mounts) > 0 || len(f.cwds) > 0 || f.here || len(f.envs) > 0 || len(f.parents) > 0 || len(f.ancestors) > 0 || len(f.ttys) > 0 || len(f.sessions) > 0 || len(f.pgids) > 0 || len(f.pids) > 0 || f.user != "" || f.where != "" || f.olderThan != "" || f.newerThan != "" || f.cpuAbove != "" || f.memAbove != "" || f.memTree || len(f.states) > 0
 }
 
 func run(f *flags, args []string) error {
@@ -156,6 +225,13 @@ func run(f *flags, args []string) error {
 		log.Warn("config load failed", "err", err)
 	}
 
+	if f.signal != "" && (f.force || f.graceful) {
+		return &exitError{code: 1, message: "--signal cannot be combined with --force or --graceful"}
+	}
+
+	if err := applyAliasOptions(f, cfg, args); err != nil {
+		return &exitError{code: 1, message: err.Error()}
+	}
 	f.force = f.force || cfg.DefaultForce
 	if cfg.DefaultVerbose && !f.verbose {
 		f.verbose = true
@@ -166,35 +242,93 @@ func run(f *flags, args []string) error {
 	find := finder.New(provider)
 	kill := killer.New(provider)
 
-	query := resolveQuery(f, args, cfg)
+	queries, err := resolveQueries(f, args, cfg)
+	if err != nil {
+		return &exitError{code: 1, message: err.Error()}
+	}
+
+	var sig process.Signal
+	if f.signal != "" {
+		sig, err = process.ParseSignal(f.signal)
+		if err != nil {
+			return &exitError{code: 1, message: err.Error()}
+		}
+	}
+
+	for _, c := range f.columns {
+		if !ui.IsColumn(c) {
+			return &exitError{code: 1, message: fmt.Sprintf("unknown column %q — available: %s", c, strings.Join(ui.Columns(), ", "))}
+		}
+	}
+
+	if f.sortBy != "" && !slices.Contains(filter.SortKeys(), f.sortBy) {
+		return &exitError{code: 1, message: fmt.Sprintf("unknown sort key %q — use %s", f.sortBy, strings.Join(filter.SortKeys(), ", "))}
+	}
+
+	preds, err := buildFilters(f, provider)
+	if err != nil {
+		return &exitError{code: 1, message: err.Error()}
+	}
 
 	var procs []process.Info
 	switch {
-	case f.user != "" && query == nil:
+	case f.user != "" && len(queries) == 0:
 		procs, err = find.FindByUser(f.user)
-	case query != nil:
-		q := *query
-		procs, err = find.Find(q)
+	case len(queries) > 0:
+		procs, err = find.FindAll(queries)
 		if f.user != "" {
 			procs = filterByUser(procs, f.user)
 		}
+	case len(preds) > 0:
+		procs, err = provider.List()
 	default:
 		return &exitError{code: 1, message: "no query provided — pass a port, name, PID, or use flags"}
 	}
+	var denied *finder.DeniedError
+	if errors.As(err, &denied) {
+		reportDenied(denied, f.verbose)
+		err = nil
+	}
 	if err != nil {
 		return &exitError{code: 1, message: fmt.Sprintf("find error: %v", err)}
 	}
 
+	procs = filterSelf(procs)
+	found := len(procs) > 0
+	if f.sample > 0 {
+		procs = provider.SampleCPU(procs, f.sample)
+	}
+	procs = filter.Apply(procs, preds...)
+	if f.sortBy != "" {
+		filter.Sort(procs, f.sortBy)
+	}
+
+	if len(f.excludes) > 0 {
+		var excluded []process.Info
+		procs, excluded, err = exclude(find, cfg, f.excludes, procs)
+		if err != nil {
+			return &exitError{code: 1, message: err.Error()}
+		}
+		reportExcluded(excluded, f.verbose || f.dryRun)
+	}
+
 	if len(procs) == 0 {
 		log.Info("no matching processes found")
+		if !found {
+			suggest(provider, cfg, queries)
+		}
 		return nil
 	}
 
 	if f.list {
-		fmt.Println(ui.RenderTable(procs, f.verbose))
+		fmt.Println(ui.RenderTable(procs, f.verbose, f.columns...))
 		return nil
 	}
 
+	if f.reapParent {
+		procs = filterSelf(reapParents(provider, procs))
+	}
+
 	procs = filterProtected(procs, cfg)
 	if len(procs) == 0 {
 		return &exitError{code: 1, message: "all matching processes are protected"}
@@ -209,40 +343,60 @@ func run(f *flags, args []string) error {
 			return nil
 		}
 	} else if len(procs) > 1 && !f.all && !f.dryRun {
-		fmt.Println(ui.RenderTable(procs, f.verbose))
+		fmt.Println(ui.RenderTable(procs, f.verbose, f.columns...))
 		return &exitError{code: 1, message: fmt.Sprintf("found %d processes — use -a to kill all, -i for interactive selection", len(procs))}
 	}
 
 	if !f.yes && !f.dryRun {
-		fmt.Println(ui.RenderTable(procs, f.verbose))
+		fmt.Println(ui.RenderTable(procs, f.verbose, f.columns...))
 		confirmed, err := ui.Confirm(fmt.Sprintf("Kill %d process(es)?", len(procs)))
 		if err != nil || !confirmed {
 			return &exitError{code: 130, message: "cancelled"}
 		}
 	}
 
-	timeout, err := parseTimeout(f.timeout)
-	if err != nil {
-		return &exitError{code: 1, message: fmt.Sprintf("invalid timeout: %v", err)}
+	stopUnits := f.stopUnit
+	if units := killer.Units(procs); len(units) > 0 && !stopUnits {
+		if f.yes || f.dryRun {
+			log.Warn("targets are managed by systemd and may be restarted — use --stop-unit to stop the unit instead", "units", strings.Join(units, ", "))
+		} else {
+			stopUnits, err = ui.ConfirmStopUnits(units)
+			if err != nil {
+				return &exitError{code: 130, message: "cancelled"}
+			}
+		}
+	}
+
+	timeout := cfg.GracefulTimeout
+	if f.timeout != "" {
+		timeout, err = parseTimeout(f.timeout)
+		if err != nil {
+			return &exitError{code: 1, message: fmt.Sprintf("invalid timeout: %v", err)}
+		}
 	}
 
 	action := killer.ActionTerminate
-	if f.force {
+	switch {
+	case f.signal != "":
+		action = killer.ActionSignal
+	case f.force:
 		action = killer.ActionKill
-	} else if f.graceful {
+	case f.graceful:
 		action = killer.ActionGraceful
 	}
 
 	opts := killer.Options{
-		Action:  action,
-		Tree:    f.tree,
-		Timeout: timeout,
-		DryRun:  f.dryRun,
+		Action:    action,
+		Tree:      f.tree,
+		Timeout:   timeout,
+		Signal:    sig,
+		DryRun:    f.dryRun,
+		StopUnits: stopUnits,
 	}
 
 	results := kill.Execute(procs, opts)
 
-	hasFailure := false
+	hasFailure, hasZombie := false, false
 	for _, r := range results {
 		if !f.quiet {
 			fmt.Println(killer.FormatResult(r))
@@ -250,40 +404,428 @@ func run(f *flags, args []string) error {
 		if !r.Success {
 			hasFailure = true
 		}
+		if errors.Is(r.Error, killer.ErrZombie) {
+			hasZombie = true
+		}
+	}
+	if hasZombie {
+		log.Info("zombies are gone once their parent reaps them — use --reap-parent to kill the parent instead")
 	}
 
+	if hasFailure && f.dryRun {
+		return &exitError{code: 1, message: "some processes could not be killed, even without --dry-run"}
+	}
 	if hasFailure {
 		return &exitError{code: 1, message: "some processes could not be killed"}
 	}
 	return nil
 }
 
-func resolveQuery(f *flags, args []string, cfg *config.Config) *detect.Query {
-	if f.port > 0 {
-		q := detect.Query{Type: detect.TypePort, Port: f.port, Raw: fmt.Sprintf("%d", f.port)}
-		return &q
+func resolveQueries(f *flags, args []string, cfg *config.Config) ([]detect.Query, error) {
+	var queries []detect.Query
+	for _, raw := range f.ports {
+		q, ok := detect.ParsePort(raw)
+		if !ok {
+			if pq, err := detect.Parse(raw); err == nil && pq.Proto != "" {
+				q, ok = pq, true
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid port %q — use a number, range like 3000-3010, or tcp:/udp: prefix", raw)
+		}
+		queries = append(queries, q)
 	}
-	if f.pid > 0 {
-		q := detect.Query{Type: detect.TypePID, PID: f.pid, Raw: fmt.Sprintf("%d", f.pid)}
-		return &q
+	for _, svc := range f.services {
+		q, err := detect.Parse("svc:" + svc)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, remote := range f.remotes {
+		q, err := detect.Parse("remote:" + remote)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, pid := range f.pids {
+		queries = append(queries, detect.Query{Type: detect.TypePID, PID: pid, Raw: fmt.Sprintf("%d", pid)})
+	}
+	for _, name := range f.names {
+		_, isAlias := cfg.Aliases[name]
+		for _, input := range cfg.ResolveAlias(name) {
+			q, err := detect.Parse(input)
+			if err != nil {
+				return nil, err
+			}
+			if !isAlias && !q.Explicit && (q.Type == detect.TypePort || q.Type == detect.TypePortRange || q.Type == detect.TypePID) {
+				q.Type = detect.TypeName
+				q.Name = input
+			}
+			queries = append(queries, q)
+		}
 	}
-	if f.name != "" {
-		input := cfg.ResolveAlias(f.name)
-		q := detect.Classify(input)
-		if q.Type == detect.TypePort || q.Type == detect.TypePID {
-			q.Type = detect.TypeName
-			q.Name = input
+	if len(f.regexes) > 0 {
+		switch f.regexField {
+		case "", detect.FieldName, detect.FieldCmdline, detect.FieldExe:
+		default:
+			return nil, fmt.Errorf("invalid regex field %q — use name, cmdline, or exe", f.regexField)
+		}
+	}
+	for _, pattern := range f.regexes {
+		queries = append(queries, detect.Regex(pattern, f.regexField))
+	}
+	for _, path := range f.files {
+		q, err := detect.Parse("file:" + path)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, path := range f.mounts {
+		q, err := detect.Parse("mount:" + path)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	cwds := f.cwds
+	if f.here {
+		dir, err := hereDir()
+		if err != nil {
+			return nil, err
+		}
+		cwds = append(cwds, dir)
+	}
+	for _, path := range cwds {
+		q, err := detect.Parse("cwd:" + path)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, env := range f.envs {
+		q, err := detect.Parse("env:" + env)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, parent := range f.parents {
+		q, err := detect.Parse("children:" + parent)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, ancestor := range f.ancestors {
+		q, err := detect.Parse("descendants:" + ancestor)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, tty := range f.ttys {
+		q, err := detect.Parse("tty:" + tty)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, sid := range f.sessions {
+		q, err := detect.Parse(fmt.Sprintf("sid:%d", sid))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, pgid := range f.pgids {
+		q, err := detect.Parse(fmt.Sprintf("pgid:%d", pgid))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	for _, arg := range args {
+		for _, input := range cfg.ResolveAlias(arg) {
+			q, err := detect.Parse(input)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, q)
+		}
+	}
+	queries, err := resolveServices(queries, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if f.sockState != "" {
+		state := strings.ToUpper(f.sockState)
+		if state != "ANY" && !slices.Contains(process.SocketStates, state) {
+			return nil, fmt.Errorf("invalid socket state %q — use any or one of %s", f.sockState, strings.Join(process.SocketStates, ", "))
+		}
+		for i, q := range queries {
+			switch q.Type {
+			case detect.TypePort, detect.TypeHostPort, detect.TypePortRange, detect.TypeRemote:
+				queries[i].State = state
+			}
+		}
+	}
+	if f.fuzzy {
+		for i, q := range queries {
+			if q.Type == detect.TypeName {
+				queries[i].Type = detect.TypeFuzzy
+			}
+		}
+	}
+	return queries, nil
+}
+
+func resolveServices(queries []detect.Query, cfg *config.Config) ([]detect.Query, error) {
+	for i, q := range queries {
+		resolved, err := resolveService(q, cfg)
+		if err != nil {
+			return nil, err
+		}
+		queries[i] = resolved
+	}
+	return queries, nil
+}
+
+func resolveService(q detect.Query, cfg *config.Config) (detect.Query, error) {
+	if q.Parent != nil {
+		parent, err := resolveService(*q.Parent, cfg)
+		if err != nil {
+			return detect.Query{}, err
 		}
-		return &q
+		q.Parent = &parent
+		return q, nil
 	}
-	if len(args) > 0 {
-		input := cfg.ResolveAlias(args[0])
-		q := detect.Classify(input)
-		return &q
+	if q.Type != detect.TypeService {
+		return q, nil
+	}
+	return detect.ResolveService(q, cfg.Services)
+}
+
+func applyAliasOptions(f *flags, cfg *config.Config, args []string) error {
+	inputs := slices.Concat(f.names, args)
+	var withOptions []string
+	for _, name := range inputs {
+		if a, ok := cfg.Aliases[name]; ok && a.HasOptions() {
+			withOptions = append(withOptions, name)
+		}
+	}
+	if len(withOptions) == 0 {
+		return nil
+	}
+
+	first := withOptions[0]
+	a := cfg.Aliases[first]
+	for _, name := range withOptions[1:] {
+		if !cfg.Aliases[name].SameOptions(a) {
+			return fmt.Errorf("aliases %q and %q set different options — run them separately", first, name)
+		}
+	}
+	if len(withOptions) < len(inputs) || hasQuerySourceFlags(f) {
+		return fmt.Errorf("alias %q sets its own options and cannot be combined with other queries — run it separately", first)
+	}
+
+	explicitAction := f.signal != "" || f.force || f.graceful
+	f.tree = f.tree || a.Tree
+	if !explicitAction {
+		f.force = a.Force
+		f.signal = a.Signal
+	}
+	if f.timeout == "" && a.Timeout > 0 {
+		f.timeout = a.Timeout.String()
 	}
 	return nil
 }
 
+func hasQuerySourceFlags(f *flags) bool {
+	return len(f.ports) > 0 || len(f.remotes) > 0 || len(f.services) > 0 || len(f.regexes) > 0 || len(f.files) > 0 || len(f.mounts) > 0 || len(f.cwds) > 0 || f.here || len(f.envs) > 0 || len(f.parents) > 0 || len(f.ancestors) > 0 || len(f.ttys) > 0 || len(f.sessions) > 0 || len(f.pgids) > 0 || len(f.pids) > 0
+}
+
+func hereDir() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine current directory: %w", err)
+	}
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return wd, nil
+		}
+	}
+}
+
+func buildFilters(f *flags, provider process.Provider) ([]filter.Predicate, error) {
+	var preds []filter.Predicate
+	if f.where != "" {
+		where, err := filter.Parse(f.where)
+		if err != nil {
+			return nil, errors.New(formatWhereError(err))
+		}
+		preds = append(preds, where)
+	}
+	if f.olderThan != "" {
+		d, err := filter.ParseDuration(f.olderThan)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --older-than: %v", err)
+		}
+		preds = append(preds, filter.OlderThan(d))
+	}
+	if f.newerThan != "" {
+		d, err := filter.ParseDuration(f.newerThan)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --newer-than: %v", err)
+		}
+		preds = append(preds, filter.NewerThan(d))
+	}
+	if len(f.states) > 0 {
+		states := make([]string, 0, len(f.states))
+		for _, raw := range f.states {
+			state, err := filter.ParseState(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --state: %v", err)
+			}
+			states = append(states, state)
+		}
+		preds = append(preds, filter.InState(states...))
+	}
+	if f.cpuAbove != "" {
+		percent, err := filter.ParsePercent(f.cpuAbove)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --cpu-above: %v", err)
+		}
+		preds = append(preds, filter.CPUAbove(percent))
+	}
+	if f.memTree && f.memAbove == "" {
+		return nil, errors.New("--mem-tree requires --mem-above")
+	}
+	if f.memAbove != "" {
+		bytes, err := filter.ParseMemory(f.memAbove)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --mem-above: %v", err)
+		}
+		var treeRSS map[int32]uint64
+		if f.memTree {
+			all, err := provider.List()
+			if err != nil {
+				return nil, fmt.Errorf("cannot list processes: %v", err)
+			}
+			treeRSS = filter.TreeRSS(all)
+		}
+		preds = append(preds, filter.MemAbove(bytes, treeRSS))
+	}
+	return preds, nil
+}
+
+func formatWhereError(err error) string {
+	var pe *filter.ParseError
+	if !errors.As(err, &pe) {
+		return fmt.Sprintf("invalid --where expression: %v", err)
+	}
+	return fmt.Sprintf("invalid --where expression at %v\n\n%s", pe, pe.Pointer())
+}
+
+func exclude(find *finder.Finder, cfg *config.Config, patterns []string, procs []process.Info) ([]process.Info, []process.Info, error) {
+	var queries []detect.Query
+	for _, pattern := range patterns {
+		for _, input := range cfg.ResolveAlias(pattern) {
+			q, err := detect.Parse(input)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid --exclude %q: %v", pattern, err)
+			}
+			queries = append(queries, q)
+		}
+	}
+
+	queries, err := resolveServices(queries, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --exclude: %v", err)
+	}
+	matches, err := find.FindAll(queries)
+	var denied *finder.DeniedError
+	if err != nil && !errors.As(err, &denied) {
+		return nil, nil, fmt.Errorf("--exclude: %v", err)
+	}
+	skip := make(map[int32]bool, len(matches))
+	for _, p := range matches {
+		skip[p.PID] = true
+	}
+
+	var kept, excluded []process.Info
+	for _, p := range procs {
+		if skip[p.PID] {
+			excluded = append(excluded, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	return kept, excluded, nil
+}
+
+func reportExcluded(excluded []process.Info, show bool) {
+	if len(excluded) == 0 {
+		return
+	}
+	log.Info(fmt.Sprintf("excluded %d process(es)", len(excluded)))
+	if show {
+		fmt.Println(ui.RenderTable(excluded, false))
+	}
+}
+
+func suggest(provider process.Provider, cfg *config.Config, queries []detect.Query) {
+	var names []string
+	for _, q := range queries {
+		if q.Type == detect.TypeName || q.Type == detect.TypeFuzzy {
+			names = append(names, q.Name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	all, err := provider.List()
+	if err != nil {
+		return
+	}
+	var candidates []string
+	for _, p := range all {
+		candidates = append(candidates, p.Name)
+		if p.Exe != "" {
+			candidates = append(candidates, filepath.Base(p.Exe))
+		}
+	}
+	for alias := range cfg.Aliases {
+		candidates = append(candidates, alias)
+	}
+
+	for _, name := range names {
+		var parts []string
+		for _, m := range fuzzy.Rank(name, candidates, suggestThreshold) {
+			if m.Score == 1 {
+				continue
+			}
+			label := fmt.Sprintf("%s (%.0f%%)", m.Value, m.Score*100)
+			if _, ok := cfg.Aliases[m.Value]; ok {
+				label = fmt.Sprintf("%s (alias, %.0f%%)", m.Value, m.Score*100)
+			}
+			parts = append(parts, label)
+			if len(parts) == maxSuggestions {
+				break
+			}
+		}
+		if len(parts) > 0 {
+			log.Info(fmt.Sprintf("did you mean %s?", strings.Join(parts, ", ")), "query", name)
+		}
+	}
+}
+
 func filterByUser(procs []process.Info, user string) []process.Info {
 	var result []process.Info
 	for _, p := range procs {
@@ -294,6 +836,51 @@ func filterByUser(procs []process.Info, user string) []process.Info {
 	return result
 }
 
+func reportDenied(denied *finder.DeniedError, verbose bool) {
+	log.Warn(fmt.Sprintf("%s — these processes were not matched", denied.Error()))
+	if verbose {
+		fmt.Println(ui.RenderTable(denied.Procs, false))
+	} else {
+		log.Info("run with -v to list them, or with elevated privileges to include them")
+	}
+}
+
+func filterSelf(procs []process.Info) []process.Info {
+	self := process.SelfAndAncestors()
+	var result []process.Info
+	for _, p := range procs {
+		if self[p.PID] {
+			log.Debug("skipping hdf or its parent", "name", p.Name, "pid", p.PID)
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+func reapParents(provider process.Provider, procs []process.Info) []process.Info {
+	seen := make(map[int32]bool)
+	var result []process.Info
+	for _, p := range procs {
+		target := p
+		if p.State == process.StateZombie && p.PPID > 1 {
+			parent, err := provider.FindByPID(p.PPID)
+			if err != nil {
+				log.Warn("cannot find parent of zombie", "name", p.Name, "pid", p.PID, "ppid", p.PPID, "err", err)
+			} else {
+				log.Info("targeting the parent of a zombie", "zombie", p.PID, "parent", parent.Name, "pid", parent.PID)
+				target = *parent
+			}
+		}
+		if seen[target.PID] {
+			continue
+		}
+		seen[target.PID] = true
+		result = append(result, target)
+	}
+	return result
+}
+
 func filterProtected(procs []process.Info, cfg *config.Config) []process.Info {
 	var result []process.Info
 	for _, p := range procs {
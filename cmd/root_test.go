@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/config"
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/finder"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type pidProvider struct {
+	process.Provider
+	procs map[int32]process.Info
+}
+
+func (p pidProvider) FindByPID(pid int32) (*process.Info, error) {
+	if info, ok := p.procs[pid]; ok {
+		return &info, nil
+	}
+	return nil, process.ErrNotFound
+}
+
+func TestExclude(t *testing.T) {
+	procs := []process.Info{
+		{PID: 10, Name: "node"},
+		{PID: 20, Name: "node"},
+		{PID: 30, Name: "redis-server"},
+	}
+	provider := pidProvider{procs: make(map[int32]process.Info)}
+	for _, p := range procs {
+		provider.procs[p.PID] = p
+	}
+	find := finder.New(provider)
+	cfg := &config.Config{Aliases: map[string]config.Alias{}}
+
+	tests := []struct {
+		patterns []string
+		kept     []int32
+		excluded []int32
+	}{
+		{patterns: []string{"pid:999999"}, kept: []int32{10, 20, 30}},
+		{patterns: []string{"pid:20", "pid:999999"}, kept: []int32{10, 30}, excluded: []int32{20}},
+		{patterns: []string{"pid:10", "pid:30"}, kept: []int32{20}, excluded: []int32{10, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.patterns[0], func(t *testing.T) {
+			kept, excluded, err := exclude(find, cfg, tt.patterns, procs)
+			if err != nil {
+				t.Fatalf("exclude(%v): %v", tt.patterns, err)
+			}
+			if got := pids(kept); !slices.Equal(got, tt.kept) {
+				t.Errorf("exclude(%v) kept %v, want %v", tt.patterns, got, tt.kept)
+			}
+			if got := pids(excluded); !slices.Equal(got, tt.excluded) {
+				t.Errorf("exclude(%v) excluded %v, want %v", tt.patterns, got, tt.excluded)
+			}
+		})
+	}
+}
+
+func pids(procs []process.Info) []int32 {
+	var result []int32
+	for _, p := range procs {
+		result = append(result, p.PID)
+	}
+	return result
+}
+
+func TestApplyAliasOptions(t *testing.T) {
+	cfg := &config.Config{Aliases: map[string]config.Alias{
+		"reload":  {Queries: []string{"nginx"}, Signal: "HUP", Tree: true},
+		"reload2": {Queries: []string{"php-fpm"}, Signal: "hup", Tree: true},
+		"nuke":    {Queries: []string{"node"}, Force: true},
+		"stack":   {Queries: []string{"3000", "redis-server"}},
+	}}
+
+	tests := []struct {
+		name    string
+		flags   flags
+		args    []string
+		force   bool
+		signal  string
+		tree    bool
+		wantErr string
+	}{
+		{name: "alias signal", args: []string{"reload"}, signal: "HUP", tree: true},
+		{name: "force overrides alias signal", flags: flags{force: true}, args: []string{"reload"}, force: true, tree: true},
+		{name: "graceful overrides alias signal", flags: flags{graceful: true}, args: []string{"reload"}, tree: true},
+		{name: "signal overrides alias signal", flags: flags{signal: "USR1"}, args: []string{"reload"}, signal: "USR1", tree: true},
+		{name: "alias force", args: []string{"nuke"}, force: true},
+		{name: "signal overrides alias force", flags: flags{signal: "INT"}, args: []string{"nuke"}, signal: "INT"},
+		{name: "alias by name flag", flags: flags{names: []string{"nuke"}}, force: true},
+		{name: "aliases with the same options", args: []string{"reload", "reload2"}, signal: "HUP", tree: true},
+		{name: "alias without options", args: []string{"stack", "3001"}},
+		{name: "conflicting aliases", args: []string{"reload", "nuke"}, wantErr: `aliases "reload" and "nuke" set different options`},
+		{name: "alias with another query", args: []string{"reload", "3000"}, wantErr: `alias "reload" sets its own options`},
+		{name: "alias with a plain alias", args: []string{"stack", "nuke"}, wantErr: `alias "nuke" sets its own options`},
+		{name: "alias with a query flag", flags: flags{ports: []string{"3000"}}, args: []string{"nuke"}, wantErr: `alias "nuke" sets its own options`},
+		{name: "alias with a name flag", flags: flags{names: []string{"node"}}, args: []string{"nuke"}, wantErr: `alias "nuke" sets its own options`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.flags
+			err := applyAliasOptions(&f, cfg, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("applyAliasOptions() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyAliasOptions(): %v", err)
+			}
+			if f.force != tt.force || f.signal != tt.signal || f.tree != tt.tree {
+				t.Fatalf("got force=%v signal=%q tree=%v, want force=%v signal=%q tree=%v", f.force, f.signal, f.tree, tt.force, tt.signal, tt.tree)
+			}
+		})
+	}
+}
+
+func TestResolveServices(t *testing.T) {
+	cfg := &config.Config{Services: map[string]string{"web": "8080", "dns": "udp:53"}}
+
+	tests := []struct {
+		input string
+		depth int
+		port  uint32
+		proto string
+	}{
+		{input: "svc:web", port: 8080},
+		{input: "children:svc:web", depth: 1, port: 8080},
+		{input: "descendants:svc:dns", depth: 1, port: 53, proto: "udp"},
+		{input: "children:descendants:svc:web", depth: 2, port: 8080},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := detect.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			resolved, err := resolveServices([]detect.Query{q}, cfg)
+			if err != nil {
+				t.Fatalf("resolveServices(%q): %v", tt.input, err)
+			}
+			leaf := resolved[0]
+			for range tt.depth {
+				if leaf.Parent == nil {
+					t.Fatalf("resolveServices(%q) lost its parent query", tt.input)
+				}
+				leaf = *leaf.Parent
+			}
+			if leaf.Type != detect.TypePort || leaf.Port != tt.port || leaf.Proto != tt.proto {
+				t.Fatalf("resolveServices(%q) = %s %s:%d, want port %s:%d", tt.input, leaf.Type, leaf.Proto, leaf.Port, tt.proto, tt.port)
+			}
+		})
+	}
+
+	q, err := detect.Parse("descendants:svc:nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolveServices([]detect.Query{q}, cfg); err == nil {
+		t.Fatal("resolveServices(descendants:svc:nope) succeeded, want unknown service error")
+	}
+}
+
+func TestResolveQueriesIDFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   flags
+		want    []detect.QueryType
+		wantErr string
+	}{
+		{name: "session", flags: flags{sessions: []int32{4242}}, want: []detect.QueryType{detect.TypeSession}},
+		{name: "pgid", flags: flags{pgids: []int32{4242}}, want: []detect.QueryType{detect.TypePGID}},
+		{name: "session zero", flags: flags{sessions: []int32{0}}, wantErr: `invalid session ID "0"`},
+		{name: "negative pgid", flags: flags{pgids: []int32{-1}}, wantErr: `invalid process group ID "-1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, err := resolveQueries(&tt.flags, nil, &config.Config{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveQueries() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveQueries(): %v", err)
+			}
+			var got []detect.QueryType
+			for _, q := range queries {
+				got = append(got, q.Type)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("resolveQueries() types = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
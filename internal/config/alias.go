@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Alias struct {
+	Queries []string      `mapstructure:"queries"`
+	Force   bool          `mapstructure:"force"`
+	Tree    bool          `mapstructure:"tree"`
+	Signal  string        `mapstructure:"signal"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (a Alias) HasOptions() bool {
+	return a.Force || a.Tree || a.Signal != "" || a.Timeout > 0
+}
+
+func (a Alias) SameOptions(b Alias) bool {
+	return a.Force == b.Force && a.Tree == b.Tree && strings.EqualFold(a.Signal, b.Signal) && a.Timeout == b.Timeout
+}
+
+func (a Alias) String() string {
+	quoted := make([]string, len(a.Queries))
+	for i, q := range a.Queries {
+		quoted[i] = fmt.Sprintf("%q", q)
+	}
+	s := strings.Join(quoted, ", ")
+	if len(a.Queries) != 1 {
+		s = "[" + s + "]"
+	}
+
+	var opts []string
+	if a.Force {
+		opts = append(opts, "force")
+	}
+	if a.Tree {
+		opts = append(opts, "tree")
+	}
+	if a.Signal != "" {
+		opts = append(opts, "signal="+a.Signal)
+	}
+	if a.Timeout > 0 {
+		opts = append(opts, "timeout="+a.Timeout.String())
+	}
+	if len(opts) > 0 {
+		s += " (" + strings.Join(opts, ", ") + ")"
+	}
+	return s
+}
+
+func (a Alias) tomlValue() any {
+	if !a.HasOptions() {
+		if len(a.Queries) == 1 {
+			return a.Queries[0]
+		}
+		return a.Queries
+	}
+	m := map[string]any{"queries": a.Queries}
+	if a.Force {
+		m["force"] = true
+	}
+	if a.Tree {
+		m["tree"] = true
+	}
+	if a.Signal != "" {
+		m["signal"] = a.Signal
+	}
+	if a.Timeout > 0 {
+		m["timeout"] = a.Timeout.String()
+	}
+	return m
+}
+
+func aliasDecodeHook(_ reflect.Type, to reflect.Type, data any) (any, error) {
+	if to != reflect.TypeOf(Alias{}) {
+		return data, nil
+	}
+	switch v := data.(type) {
+	case string:
+		return Alias{Queries: []string{v}}, nil
+	case []string:
+		return Alias{Queries: v}, nil
+	case []any:
+		queries := make([]string, 0, len(v))
+		for _, q := range v {
+			s, ok := q.(string)
+			if !ok {
+				return nil, fmt.Errorf("alias queries must be strings, got %v", q)
+			}
+			queries = append(queries, s)
+		}
+		return Alias{Queries: queries}, nil
+	default:
+		return data, nil
+	}
+}
@@ -11,13 +11,15 @@ import (
 	"time"
 
 	"github.com/adrg/xdg"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	GracefulTimeout time.Duration     `mapstructure:"graceful_timeout"`
 	Protected       []string          `mapstructure:"protected"`
-	Aliases         map[string]string `mapstructure:"aliases"`
+	Aliases         map[string]Alias  `mapstructure:"aliases"`
+	Services        map[string]string `mapstructure:"services"`
 	DefaultForce    bool              `mapstructure:"default_force"`
 	DefaultVerbose  bool              `mapstructure:"default_verbose"`
 	DefaultEditor   string            `mapstructure:"default_editor"`
@@ -75,12 +77,20 @@ func Load() (*Config, error) {
 	}
 
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	hook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		aliasDecodeHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))
+	if err := v.Unmarshal(&cfg, hook); err != nil {
 		return configFromDefaults(), err
 	}
 
 	if cfg.Aliases == nil {
-		cfg.Aliases = map[string]string{}
+		cfg.Aliases = map[string]Alias{}
+	}
+	if cfg.Services == nil {
+		cfg.Services = map[string]string{}
 	}
 
 	return &cfg, nil
@@ -96,11 +106,18 @@ func Save(cfg *Config) error {
 
 	for _, f := range Schema {
 		val, _ := GetValue(cfg, f.Key)
-		if f.Kind == Duration {
+		switch f.Kind {
+		case Duration:
 			v.Set(f.Key, val.(time.Duration).String())
-			continue
+		case AliasMap:
+			aliases := make(map[string]any)
+			for name, a := range val.(map[string]Alias) {
+				aliases[name] = a.tomlValue()
+			}
+			v.Set(f.Key, aliases)
+		default:
+			v.Set(f.Key, val)
 		}
-		v.Set(f.Key, val)
 	}
 
 	return v.WriteConfigAs(Path())
@@ -160,7 +177,7 @@ func ParseValue(f *Field, raw string) (any, error) {
 			parts[i] = strings.TrimSpace(parts[i])
 		}
 		return parts, nil
-	case StringMap:
+	case StringMap, AliasMap:
 		return nil, fmt.Errorf("%q is a collection type and cannot be set directly", f.Key)
 	default:
 		return nil, fmt.Errorf("unknown kind %d", f.Kind)
@@ -183,25 +200,30 @@ func FormatValue(f *Field, val any) string {
 		}
 		return "[" + strings.Join(quoted, ", ") + "]"
 	case StringMap:
-		m := val.(map[string]string)
-		if len(m) == 0 {
-			return "(none)"
-		}
-		keys := make([]string, 0, len(m))
-		for k := range m {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		parts := make([]string, 0, len(m))
-		for _, k := range keys {
-			parts = append(parts, fmt.Sprintf("%s=%q", k, m[k]))
-		}
-		return strings.Join(parts, ", ")
+		return formatMap(val.(map[string]string), func(v string) string { return fmt.Sprintf("%q", v) })
+	case AliasMap:
+		return formatMap(val.(map[string]Alias), Alias.String)
 	default:
 		return fmt.Sprintf("%v", val)
 	}
 }
 
+func formatMap[V any](m map[string]V, format func(V) string) string {
+	if len(m) == 0 {
+		return "(none)"
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(m))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, format(m[k])))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (c *Config) IsProtected(name string) bool {
 	lower := strings.ToLower(name)
 	for _, p := range c.Protected {
@@ -212,9 +234,9 @@ func (c *Config) IsProtected(name string) bool {
 	return false
 }
 
-func (c *Config) ResolveAlias(input string) string {
-	if resolved, ok := c.Aliases[input]; ok {
-		return resolved
+func (c *Config) ResolveAlias(input string) []string {
+	if a, ok := c.Aliases[input]; ok && len(a.Queries) > 0 {
+		return a.Queries
 	}
-	return input
+	return []string{input}
 }
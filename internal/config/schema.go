@@ -11,6 +11,7 @@ const (
 	Duration
 	StringSlice
 	StringMap
+	AliasMap
 )
 
 type Field struct {
@@ -75,9 +76,17 @@ var Schema = []Field{
 		Key:     "aliases",
 		Label:   "Aliases",
 		Group:   "aliases",
+		Kind:    AliasMap,
+		Default: map[string]Alias{},
+		Desc:    "Query shortcuts (name → one or more queries, with optional force, tree, signal and timeout)",
+	},
+	{
+		Key:     "services",
+		Label:   "Services",
+		Group:   "services",
 		Kind:    StringMap,
 		Default: map[string]string{},
-		Desc:    "Query shortcuts (name → target)",
+		Desc:    "Service names for svc: queries (name → port, range, or tcp:/udp: port)",
 	},
 }
 
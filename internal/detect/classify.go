@@ -14,6 +14,30 @@ const (
 	TypeHostPort
 	TypeGlob
 	TypeName
+	TypePortRange
+	TypeRegex
+	TypeUser
+	TypeExe
+	TypeCwd
+	TypeUnix
+	TypeFile
+	TypeMount
+	TypeEnv
+	TypeContainer
+	TypeUnit
+	TypeChildren
+	TypeTTY
+	TypeSession
+	TypePGID
+	TypeFuzzy
+	TypeRemote
+	TypeService
+)
+
+const (
+	FieldName    = "name"
+	FieldCmdline = "cmdline"
+	FieldExe     = "exe"
 )
 
 func (t QueryType) String() string {
@@ -28,17 +52,64 @@ func (t QueryType) String() string {
 		return "glob"
 	case TypeName:
 		return "name"
+	case TypePortRange:
+		return "port range"
+	case TypeRegex:
+		return "regex"
+	case TypeUser:
+		return "user"
+	case TypeExe:
+		return "exe"
+	case TypeCwd:
+		return "cwd"
+	case TypeUnix:
+		return "unix socket"
+	case TypeFile:
+		return "file"
+	case TypeMount:
+		return "mount"
+	case TypeEnv:
+		return "env"
+	case TypeContainer:
+		return "container"
+	case TypeUnit:
+		return "unit"
+	case TypeChildren:
+		return "children"
+	case TypeTTY:
+		return "tty"
+	case TypeSession:
+		return "session"
+	case TypePGID:
+		return "pgid"
+	case TypeFuzzy:
+		return "fuzzy"
+	case TypeRemote:
+		return "remote"
+	case TypeService:
+		return "service"
 	default:
 		return "unknown"
 	}
 }
 
 type Query struct {
-	Type QueryType
-	Raw  string
-	Port uint32
-	PID  int32
-	Name string
+	Type      QueryType
+	Raw       string
+	Host      string
+	Port      uint32
+	PortEnd   uint32
+	Proto     string
+	State     string
+	PID       int32
+	Name      string
+	Field     string
+	Path      string
+	Key       string
+	Value     string
+	Parent    *Query
+	Recursive bool
+	Explicit  bool
 }
 
 func Classify(input string) Query {
@@ -56,11 +127,19 @@ func Classify(input string) Query {
 		return q
 	}
 
+	if start, end, ok := parsePortRange(input); ok {
+		q.Type = TypePortRange
+		q.Port = start
+		q.PortEnd = end
+		return q
+	}
+
 	if strings.Contains(input, ":") {
-		_, portStr, err := net.SplitHostPort(input)
+		host, portStr, err := net.SplitHostPort(input)
 		if err == nil {
 			if port, err := strconv.ParseUint(portStr, 10, 32); err == nil && port >= 1 && port <= 65535 {
 				q.Type = TypeHostPort
+				q.Host = host
 				q.Port = uint32(port)
 				return q
 			}
@@ -77,3 +156,58 @@ func Classify(input string) Query {
 	q.Name = input
 	return q
 }
+
+func ParsePort(input string) (Query, bool) {
+	input = strings.TrimSpace(input)
+	q := Query{Raw: input}
+
+	if port, ok := parsePortNumber(input); ok {
+		q.Type = TypePort
+		q.Port = port
+		return q, true
+	}
+	if start, end, ok := parsePortRange(input); ok {
+		q.Type = TypePortRange
+		q.Port = start
+		q.PortEnd = end
+		return q, true
+	}
+	return q, false
+}
+
+func parsePortRange(input string) (uint32, uint32, bool) {
+	startStr, endStr, found := strings.Cut(input, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, ok := parsePortNumber(startStr)
+	if !ok {
+		return 0, 0, false
+	}
+	end, ok := parsePortNumber(endStr)
+	if !ok || end < start {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+func isReversedPortRange(input string) bool {
+	startStr, endStr, found := strings.Cut(input, "-")
+	if !found {
+		return false
+	}
+	start, ok := parsePortNumber(startStr)
+	if !ok {
+		return false
+	}
+	end, ok := parsePortNumber(endStr)
+	return ok && end < start
+}
+
+func parsePortNumber(s string) (uint32, bool) {
+	num, err := strconv.ParseUint(s, 10, 32)
+	if err != nil || num < 1 || num > 65535 {
+		return 0, false
+	}
+	return uint32(num), true
+}
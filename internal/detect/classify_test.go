@@ -0,0 +1,41 @@
+package detect
+
+import "testing"
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    QueryType
+		start   uint32
+		end     uint32
+		wantErr bool
+	}{
+		{input: "3000-3010", want: TypePortRange, start: 3000, end: 3010},
+		{input: "3000-3000", want: TypePortRange, start: 3000, end: 3000},
+		{input: "port:3000-3010", want: TypePortRange, start: 3000, end: 3010},
+		{input: "3010-3000", wantErr: true},
+		{input: "port:3010-3000", wantErr: true},
+		{input: "3000-99999", want: TypeName},
+		{input: "node-18", want: TypeName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.input, q)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if q.Type != tt.want {
+				t.Fatalf("Parse(%q).Type = %s, want %s", tt.input, q.Type, tt.want)
+			}
+			if q.Port != tt.start || q.PortEnd != tt.end {
+				t.Fatalf("Parse(%q) range = %d-%d, want %d-%d", tt.input, q.Port, q.PortEnd, tt.start, tt.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package detect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var prefixes map[string]func(value string) (Query, error)
+
+func init() {
+	prefixes = map[string]func(value string) (Query, error){
+		"pid":         parsePIDValue,
+		"port":        parsePortValue,
+		"name":        parseNameValue,
+		"user":        parseUserValue,
+		"exe":         parseExeValue,
+		"cwd":         parseCwdValue,
+		"unix":        parseUnixValue,
+		"file":        parseFileValue,
+		"mount":       parseMountValue,
+		"env":         parseEnvValue,
+		"container":   parseContainerValue,
+		"unit":        parseUnitValue,
+		"children":    parseChildrenValue,
+		"descendants": parseDescendantsValue,
+		"tty":         parseTTYValue,
+		"sid":         parseSessionValue,
+		"pgid":        parsePGIDValue,
+		"fuzzy":       parseFuzzyValue,
+		"tcp":         parseTCPValue,
+		"udp":         parseUDPValue,
+		"remote":      parseRemoteValue,
+		"svc":         parseServiceValue,
+	}
+}
+
+func Parse(input string) (Query, error) {
+	input = strings.TrimSpace(input)
+
+	if q, ok := parseRegex(input); ok {
+		return q, nil
+	}
+
+	prefix, value, found := strings.Cut(input, ":")
+	parse, known := prefixes[strings.ToLower(prefix)]
+	if !found || !known {
+		if isSocketFile(input) {
+			return parseUnixValue(input)
+		}
+		if isReversedPortRange(input) {
+			return Query{}, fmt.Errorf("invalid port %q — use a number, range like 3000-3010, or tcp:/udp: prefix", input)
+		}
+		return Classify(input), nil
+	}
+
+	if value == "" {
+		return Query{}, fmt.Errorf("missing value after %q", prefix+":")
+	}
+	q, err := parse(value)
+	if err != nil {
+		return Query{}, err
+	}
+	q.Raw = input
+	q.Explicit = true
+	return q, nil
+}
+
+func Regex(pattern, field string) Query {
+	return Query{Type: TypeRegex, Raw: pattern, Name: pattern, Field: field, Explicit: true}
+}
+
+func parseRegex(input string) (Query, bool) {
+	prefix, pattern, found := strings.Cut(input, ":")
+	if !found {
+		return Query{}, false
+	}
+	switch prefix {
+	case "re":
+		q := Regex(pattern, "")
+		q.Raw = input
+		return q, true
+	case "re." + FieldName, "re." + FieldCmdline, "re." + FieldExe:
+		q := Regex(pattern, strings.TrimPrefix(prefix, "re."))
+		q.Raw = input
+		return q, true
+	}
+	return Query{}, false
+}
+
+func parsePIDValue(value string) (Query, error) {
+	pid, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || pid < 1 {
+		return Query{}, fmt.Errorf("invalid PID %q", value)
+	}
+	return Query{Type: TypePID, PID: int32(pid)}, nil
+}
+
+func parsePortValue(value string) (Query, error) {
+	if q, ok := ParsePort(value); ok {
+		return q, nil
+	}
+	if q := Classify(value); q.Type == TypeHostPort {
+		return q, nil
+	}
+	return Query{}, fmt.Errorf("invalid port %q — use a number, range like 3000-3010, or host:port", value)
+}
+
+func parseTCPValue(value string) (Query, error) {
+	return parseProtoValue(value, "tcp")
+}
+
+func parseUDPValue(value string) (Query, error) {
+	return parseProtoValue(value, "udp")
+}
+
+func parseProtoValue(value, proto string) (Query, error) {
+	q, err := parsePortValue(value)
+	if err != nil {
+		return Query{}, err
+	}
+	q.Proto = proto
+	return q, nil
+}
+
+func parseRemoteValue(value string) (Query, error) {
+	if port, ok := parsePortNumber(value); ok {
+		return Query{Type: TypeRemote, Port: port}, nil
+	}
+	if q := Classify(value); q.Type == TypeHostPort {
+		q.Type = TypeRemote
+		return q, nil
+	}
+	return Query{}, fmt.Errorf("invalid remote endpoint %q — use a port or host:port", value)
+}
+
+func parseServiceValue(value string) (Query, error) {
+	return Query{Type: TypeService, Name: value}, nil
+}
+
+func parseNameValue(value string) (Query, error) {
+	if strings.ContainsAny(value, "*?") {
+		return Query{Type: TypeGlob, Name: value}, nil
+	}
+	return Query{Type: TypeName, Name: value}, nil
+}
+
+func parseFuzzyValue(value string) (Query, error) {
+	return Query{Type: TypeFuzzy, Name: value}, nil
+}
+
+func parseUserValue(value string) (Query, error) {
+	return Query{Type: TypeUser, Name: value}, nil
+}
+
+func parseExeValue(value string) (Query, error) {
+	if strings.HasPrefix(value, "~") {
+		path, err := ExpandPath(value)
+		if err != nil {
+			return Query{}, err
+		}
+		value = path
+	}
+	return Query{Type: TypeExe, Path: value}, nil
+}
+
+func parseCwdValue(value string) (Query, error) {
+	path, err := ExpandPath(value)
+	if err != nil {
+		return Query{}, err
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return Query{Type: TypeCwd, Path: path}, nil
+}
+
+func parseUnixValue(value string) (Query, error) {
+	if strings.HasPrefix(value, "@") {
+		return Query{Type: TypeUnix, Raw: value, Path: value}, nil
+	}
+	path, err := ExpandPath(value)
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{Type: TypeUnix, Raw: value, Path: path}, nil
+}
+
+func parseFileValue(value string) (Query, error) {
+	path, err := ExpandPath(value)
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{Type: TypeFile, Raw: value, Path: path}, nil
+}
+
+func parseMountValue(value string) (Query, error) {
+	q, err := parseFileValue(value)
+	if err != nil {
+		return Query{}, err
+	}
+	q.Type = TypeMount
+	return q, nil
+}
+
+func parseEnvValue(value string) (Query, error) {
+	key, val, hasValue := strings.Cut(value, "=")
+	if key == "" {
+		return Query{}, fmt.Errorf("invalid environment query %q — use KEY or KEY=VALUE", value)
+	}
+	if !hasValue {
+		val = "*"
+	}
+	return Query{Type: TypeEnv, Key: key, Value: val}, nil
+}
+
+func parseContainerValue(value string) (Query, error) {
+	return Query{Type: TypeContainer, Name: value}, nil
+}
+
+func parseUnitValue(value string) (Query, error) {
+	if !strings.Contains(value, ".") && !strings.ContainsAny(value, "*?") {
+		value += ".service"
+	}
+	return Query{Type: TypeUnit, Name: value}, nil
+}
+
+func parseChildrenValue(value string) (Query, error) {
+	parent, err := ParseParent(value)
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{Type: TypeChildren, Parent: &parent}, nil
+}
+
+func parseDescendantsValue(value string) (Query, error) {
+	q, err := parseChildrenValue(value)
+	if err != nil {
+		return Query{}, err
+	}
+	q.Recursive = true
+	return q, nil
+}
+
+func parseTTYValue(value string) (Query, error) {
+	return Query{Type: TypeTTY, Name: strings.TrimPrefix(value, "/dev/")}, nil
+}
+
+func parseSessionValue(value string) (Query, error) {
+	q, err := parsePIDValue(value)
+	if err != nil {
+		return Query{}, fmt.Errorf("invalid session ID %q", value)
+	}
+	q.Type = TypeSession
+	return q, nil
+}
+
+func parsePGIDValue(value string) (Query, error) {
+	q, err := parsePIDValue(value)
+	if err != nil {
+		return Query{}, fmt.Errorf("invalid process group ID %q", value)
+	}
+	q.Type = TypePGID
+	return q, nil
+}
+
+func ParseParent(input string) (Query, error) {
+	q, err := Parse(input)
+	if err != nil {
+		return Query{}, err
+	}
+	if !q.Explicit && q.Type == TypePort {
+		q.Type = TypePID
+		q.PID = int32(q.Port)
+		q.Port = 0
+	}
+	return q, nil
+}
+
+func isSocketFile(input string) bool {
+	if !strings.ContainsRune(input, filepath.Separator) {
+		return false
+	}
+	info, err := os.Stat(input)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
+
+func ExpandPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand %q: %w", path, err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	return abs, nil
+}
@@ -0,0 +1,72 @@
+package detect
+
+import "testing"
+
+func TestParseEnvValue(t *testing.T) {
+	tests := []struct {
+		input   string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{input: "env:SERVICE", key: "SERVICE", value: "*"},
+		{input: "env:SERVICE=billing", key: "SERVICE", value: "billing"},
+		{input: "env:SERVICE=billing/*", key: "SERVICE", value: "billing/*"},
+		{input: "env:URL=http://a=b", key: "URL", value: "http://a=b"},
+		{input: "env:EMPTY=", key: "EMPTY", value: ""},
+		{input: "env:", wantErr: true},
+		{input: "env:=value", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.input, q)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if q.Type != TypeEnv || q.Key != tt.key || q.Value != tt.value {
+				t.Fatalf("Parse(%q) = %s %q=%q, want env %q=%q", tt.input, q.Type, q.Key, q.Value, tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestParseIDValues(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    QueryType
+		id      int32
+		wantErr bool
+	}{
+		{input: "sid:4242", want: TypeSession, id: 4242},
+		{input: "pgid:4242", want: TypePGID, id: 4242},
+		{input: "pid:1", want: TypePID, id: 1},
+		{input: "sid:0", wantErr: true},
+		{input: "pgid:0", wantErr: true},
+		{input: "pgid:-1", wantErr: true},
+		{input: "sid:abc", wantErr: true},
+		{input: "sid:99999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.input, q)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if q.Type != tt.want || q.PID != tt.id {
+				t.Fatalf("Parse(%q) = %s %d, want %s %d", tt.input, q.Type, q.PID, tt.want, tt.id)
+			}
+		})
+	}
+}
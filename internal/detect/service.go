@@ -0,0 +1,40 @@
+package detect
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+func ResolveService(q Query, custom map[string]string) (Query, error) {
+	name := strings.ToLower(q.Name)
+	if value, ok := custom[name]; ok {
+		resolved, err := parseServicePort(value)
+		if err != nil {
+			return Query{}, fmt.Errorf("service %q: %w", q.Name, err)
+		}
+		resolved.Raw = q.Raw
+		resolved.Explicit = true
+		return resolved, nil
+	}
+
+	port, err := net.LookupPort("tcp", name)
+	if err != nil {
+		port, err = net.LookupPort("udp", name)
+	}
+	if err != nil {
+		return Query{}, fmt.Errorf("unknown service %q — add it to the services table in the config", q.Name)
+	}
+	return Query{Type: TypePort, Raw: q.Raw, Port: uint32(port), Explicit: true}, nil
+}
+
+func parseServicePort(value string) (Query, error) {
+	prefix, rest, found := strings.Cut(value, ":")
+	switch strings.ToLower(prefix) {
+	case "tcp", "udp":
+		if found {
+			return parseProtoValue(rest, strings.ToLower(prefix))
+		}
+	}
+	return parsePortValue(value)
+}
@@ -0,0 +1,219 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type Predicate func(process.Info) bool
+
+func Apply(procs []process.Info, preds ...Predicate) []process.Info {
+	if len(preds) == 0 {
+		return procs
+	}
+	var result []process.Info
+	for _, p := range procs {
+		if matchAll(p, preds) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func matchAll(info process.Info, preds []Predicate) bool {
+	for _, pred := range preds {
+		if !pred(info) {
+			return false
+		}
+	}
+	return true
+}
+
+func OlderThan(d time.Duration) Predicate {
+	return func(info process.Info) bool {
+		return !info.CreateTime.IsZero() && time.Since(info.CreateTime) > d
+	}
+}
+
+func NewerThan(d time.Duration) Predicate {
+	return func(info process.Info) bool {
+		return !info.CreateTime.IsZero() && time.Since(info.CreateTime) < d
+	}
+}
+
+func CPUAbove(percent float64) Predicate {
+	return func(info process.Info) bool {
+		return info.CPUPercent > percent
+	}
+}
+
+func MemAbove(bytes uint64, treeRSS map[int32]uint64) Predicate {
+	return func(info process.Info) bool {
+		if treeRSS != nil {
+			return treeRSS[info.PID] > bytes
+		}
+		return info.MemRSS > bytes
+	}
+}
+
+func TreeRSS(all []process.Info) map[int32]uint64 {
+	own := make(map[int32]uint64, len(all))
+	children := make(map[int32][]int32)
+	for _, p := range all {
+		own[p.PID] = p.MemRSS
+		if p.PPID != p.PID {
+			children[p.PPID] = append(children[p.PPID], p.PID)
+		}
+	}
+
+	totals := make(map[int32]uint64, len(all))
+	var sum func(pid int32, seen map[int32]bool) uint64
+	sum = func(pid int32, seen map[int32]bool) uint64 {
+		if total, ok := totals[pid]; ok {
+			return total
+		}
+		seen[pid] = true
+		total := own[pid]
+		for _, child := range children[pid] {
+			if !seen[child] {
+				total += sum(child, seen)
+			}
+		}
+		totals[pid] = total
+		return total
+	}
+	for _, p := range all {
+		sum(p.PID, make(map[int32]bool))
+	}
+	return totals
+}
+
+var stateNames = map[string]string{
+	"r":               process.StateRunning,
+	"running":         process.StateRunning,
+	"s":               process.StateSleeping,
+	"sleeping":        process.StateSleeping,
+	"d":               process.StateDiskWait,
+	"disk":            process.StateDiskWait,
+	"uninterruptible": process.StateDiskWait,
+	"z":               process.StateZombie,
+	"zombie":          process.StateZombie,
+	"t":               process.StateStopped,
+	"stopped":         process.StateStopped,
+	"i":               process.StateIdle,
+	"idle":            process.StateIdle,
+}
+
+func ParseState(s string) (string, error) {
+	state, ok := stateNames[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return "", fmt.Errorf("invalid state %q — use R, S, D, Z, T, I or running, sleeping, disk, zombie, stopped, idle", s)
+	}
+	return state, nil
+}
+
+func InState(states ...string) Predicate {
+	return func(info process.Info) bool {
+		return slices.Contains(states, info.State)
+	}
+}
+
+func ParsePercent(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid percentage %q", s)
+	}
+	return n, nil
+}
+
+func ParseMemory(s string) (uint64, error) {
+	if !strings.HasSuffix(strings.TrimSpace(s), "%") {
+		return ParseSize(s)
+	}
+	percent, err := ParsePercent(s)
+	if err != nil {
+		return 0, err
+	}
+	total, err := process.TotalMemory()
+	if err != nil {
+		return 0, fmt.Errorf("cannot determine total memory: %w", err)
+	}
+	return uint64(percent / 100 * float64(total)), nil
+}
+
+func ParseSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+	upper = strings.TrimSuffix(upper, "IB")
+	upper = strings.TrimSuffix(upper, "B")
+
+	multiplier := uint64(1)
+	if upper != "" {
+		switch upper[len(upper)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		case 'T':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			upper = upper[:len(upper)-1]
+		}
+	}
+
+	num, err := strconv.ParseFloat(upper, 64)
+	if err != nil || num < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return uint64(num * float64(multiplier)), nil
+}
+
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var total time.Duration
+	rest := s
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(rest) && (rest[j] < '0' || rest[j] > '9') && rest[j] != '.' {
+			j++
+		}
+		if i == 0 || j == i {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		num, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		switch unit := rest[i:j]; unit {
+		case "w":
+			total += time.Duration(num * float64(7*24*time.Hour))
+		case "d":
+			total += time.Duration(num * float64(24*time.Hour))
+		default:
+			d, err := time.ParseDuration(rest[:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += d
+		}
+		rest = rest[j:]
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return total, nil
+}
@@ -0,0 +1,41 @@
+package filter
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+var sortKeys = map[string]func(a, b process.Info) int{
+	"pid":  func(a, b process.Info) int { return cmp.Compare(a.PID, b.PID) },
+	"name": func(a, b process.Info) int { return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)) },
+	"port": func(a, b process.Info) int { return cmp.Compare(lowestPort(a), lowestPort(b)) },
+	"cpu":  func(a, b process.Info) int { return cmp.Compare(b.CPUPercent, a.CPUPercent) },
+	"mem":  func(a, b process.Info) int { return cmp.Compare(b.MemRSS, a.MemRSS) },
+	"age":  func(a, b process.Info) int { return a.CreateTime.Compare(b.CreateTime) },
+}
+
+func SortKeys() []string {
+	keys := make([]string, 0, len(sortKeys))
+	for key := range sortKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func Sort(procs []process.Info, key string) {
+	if compare, ok := sortKeys[key]; ok {
+		slices.SortStableFunc(procs, compare)
+	}
+}
+
+func lowestPort(p process.Info) uint32 {
+	if ports := p.Ports(); len(ports) > 0 {
+		return ports[0]
+	}
+	return 0
+}
@@ -0,0 +1,410 @@
+package filter
+
+import (
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type ParseError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+func (e *ParseError) Pointer() string {
+	return e.Expr + "\n" + strings.Repeat(" ", e.Pos) + "^"
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case c == '&' || c == '|':
+			if i+1 >= len(expr) || expr[i+1] != c {
+				return nil, &ParseError{Expr: expr, Pos: i, Msg: fmt.Sprintf("expected %q", string([]byte{c, c}))}
+			}
+			kind := tokAnd
+			if c == '|' {
+				kind = tokOr
+			}
+			tokens = append(tokens, token{kind, expr[i : i+2], i})
+			i += 2
+		case c == '!':
+			if i+1 < len(expr) && (expr[i+1] == '=' || expr[i+1] == '~') {
+				tokens = append(tokens, token{tokOp, expr[i : i+2], i})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{tokNot, "!", i})
+			i++
+		case c == '=':
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				tokens = append(tokens, token{tokOp, "==", i})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{tokOp, "==", i})
+			i++
+		case c == '<' || c == '>':
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				tokens = append(tokens, token{tokOp, expr[i : i+2], i})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{tokOp, string(c), i})
+			i++
+		case c == '~':
+			tokens = append(tokens, token{tokOp, "~", i})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, &ParseError{Expr: expr, Pos: i, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{tokString, expr[i+1 : i+1+end], i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\n()&|!=<>~\"'", rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, expr[start:i], start})
+		}
+	}
+	tokens = append(tokens, token{tokEOF, "", len(expr)})
+	return tokens, nil
+}
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindNumber
+	kindPercent
+	kindSize
+	kindDuration
+)
+
+type field struct {
+	kind valueKind
+	str  func(process.Info) string
+	num  func(process.Info) float64
+	nums func(process.Info) []float64
+}
+
+var fields = map[string]field{
+	"name":      {kind: kindString, str: func(p process.Info) string { return p.Name }},
+	"cmdline":   {kind: kindString, str: func(p process.Info) string { return p.Cmdline }},
+	"exe":       {kind: kindString, str: func(p process.Info) string { return p.Exe }},
+	"user":      {kind: kindString, str: func(p process.Info) string { return p.User }},
+	"container": {kind: kindString, str: func(p process.Info) string { return p.ContainerID }},
+	"runtime":   {kind: kindString, str: func(p process.Info) string { return p.ContainerRuntime }},
+	"unit":      {kind: kindString, str: func(p process.Info) string { return p.Unit }},
+	"state":     {kind: kindString, str: func(p process.Info) string { return p.State }},
+	"wchan":     {kind: kindString, str: func(p process.Info) string { return p.WChan }},
+	"tty":       {kind: kindString, str: func(p process.Info) string { return p.TTY }},
+	"sid":       {kind: kindNumber, num: func(p process.Info) float64 { return float64(p.SID) }},
+	"pgid":      {kind: kindNumber, num: func(p process.Info) float64 { return float64(p.PGID) }},
+	"pid":       {kind: kindNumber, num: func(p process.Info) float64 { return float64(p.PID) }},
+	"ppid":      {kind: kindNumber, num: func(p process.Info) float64 { return float64(p.PPID) }},
+	"port":      {kind: kindNumber, nums: ports},
+	"cpu":       {kind: kindPercent, num: func(p process.Info) float64 { return p.CPUPercent }},
+	"rss":       {kind: kindSize, num: func(p process.Info) float64 { return float64(p.MemRSS) }},
+	"mem":       {kind: kindSize, num: func(p process.Info) float64 { return float64(p.MemRSS) }},
+	"age":       {kind: kindDuration, nums: age},
+}
+
+func fieldNames() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+type parser struct {
+	expr   string
+	tokens []token
+	pos    int
+}
+
+func Parse(expr string) (Predicate, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expr: expr, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, p.errorf(p.peek(), "empty expression")
+	}
+
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+	return pred, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Expr: p.expr, Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr() (Predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l, r := left, right
+		left = func(info process.Info) bool { return l(info) || r(info) }
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l, r := left, right
+		left = func(info process.Info) bool { return l(info) && r(info) }
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Predicate, error) {
+	if p.peek().kind == tokNot {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(info process.Info) bool { return !inner(info) }, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Predicate, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorf(closing, "expected \")\", got %s", closing)
+		}
+		return inner, nil
+	case tokWord:
+		return p.parseComparison(tok)
+	default:
+		return nil, p.errorf(tok, "expected field name, got %s", tok)
+	}
+}
+
+func (p *parser) parseComparison(name token) (Predicate, error) {
+	key := strings.ToLower(name.text)
+	f, ok := fields[key]
+	if !ok {
+		return nil, p.errorf(name, "unknown field %q (valid: %s)", name.text, fieldNames())
+	}
+
+	op := p.next()
+	if op.kind != tokOp {
+		return nil, p.errorf(op, "expected comparison operator after %q, got %s", name.text, op)
+	}
+
+	val := p.next()
+	if val.kind != tokWord && val.kind != tokString {
+		return nil, p.errorf(val, "expected value after %q, got %s", op.text, val)
+	}
+
+	if f.kind == kindString {
+		return p.stringComparison(key, f, op, val)
+	}
+	return p.numberComparison(f, op, val)
+}
+
+func (p *parser) stringComparison(key string, f field, op, val token) (Predicate, error) {
+	want := val.text
+	if key == "user" && val.kind == tokWord && want == "me" {
+		u, err := user.Current()
+		if err != nil {
+			return nil, p.errorf(val, "cannot determine current user: %v", err)
+		}
+		want = u.Username
+	}
+	if key == "state" && op.text != "~" && op.text != "!~" {
+		state, err := ParseState(want)
+		if err != nil {
+			return nil, p.errorf(val, "%v", err)
+		}
+		want = state
+	}
+
+	switch op.text {
+	case "==":
+		return func(info process.Info) bool { return strings.EqualFold(f.str(info), want) }, nil
+	case "!=":
+		return func(info process.Info) bool { return !strings.EqualFold(f.str(info), want) }, nil
+	case "~", "!~":
+		pattern := strings.ToLower(want)
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, p.errorf(val, "invalid glob pattern %q", want)
+		}
+		negate := op.text == "!~"
+		return func(info process.Info) bool {
+			matched, _ := filepath.Match(pattern, strings.ToLower(f.str(info)))
+			return matched != negate
+		}, nil
+	default:
+		return nil, p.errorf(op, "operator %q is not valid for text fields (use ==, !=, ~, !~)", op.text)
+	}
+}
+
+func (p *parser) numberComparison(f field, op, val token) (Predicate, error) {
+	want, err := parseValue(f.kind, val.text)
+	if err != nil {
+		return nil, p.errorf(val, "%v", err)
+	}
+
+	var cmp func(a, b float64) bool
+	switch op.text {
+	case "==":
+		cmp = func(a, b float64) bool { return a == b }
+	case "!=":
+		cmp = func(a, b float64) bool { return a != b }
+	case "<":
+		cmp = func(a, b float64) bool { return a < b }
+	case "<=":
+		cmp = func(a, b float64) bool { return a <= b }
+	case ">":
+		cmp = func(a, b float64) bool { return a > b }
+	case ">=":
+		cmp = func(a, b float64) bool { return a >= b }
+	default:
+		return nil, p.errorf(op, "operator %q is not valid for numeric fields (use ==, !=, <, <=, >, >=)", op.text)
+	}
+	if f.nums != nil {
+		if op.text == "!=" {
+			return func(info process.Info) bool {
+				return !slices.ContainsFunc(f.nums(info), func(v float64) bool { return v == want })
+			}, nil
+		}
+		return func(info process.Info) bool {
+			return slices.ContainsFunc(f.nums(info), func(v float64) bool { return cmp(v, want) })
+		}, nil
+	}
+	return func(info process.Info) bool { return cmp(f.num(info), want) }, nil
+}
+
+func age(p process.Info) []float64 {
+	if p.CreateTime.IsZero() {
+		return nil
+	}
+	return []float64{float64(time.Since(p.CreateTime))}
+}
+
+func ports(p process.Info) []float64 {
+	values := make([]float64, 0, len(p.Bindings))
+	for _, port := range p.Ports() {
+		values = append(values, float64(port))
+	}
+	return values
+}
+
+func parseValue(kind valueKind, raw string) (float64, error) {
+	switch kind {
+	case kindSize:
+		n, err := ParseMemory(raw)
+		return float64(n), err
+	case kindDuration:
+		d, err := ParseDuration(raw)
+		return float64(d), err
+	case kindPercent:
+		return ParsePercent(raw)
+	default:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", raw)
+		}
+		return n, nil
+	}
+}
@@ -0,0 +1,172 @@
+package filter
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []token
+	}{
+		{
+			expr: `pid>=10`,
+			want: []token{{tokWord, "pid", 0}, {tokOp, ">=", 3}, {tokWord, "10", 5}},
+		},
+		{
+			expr: `name="node" || !(user!=me)`,
+			want: []token{
+				{tokWord, "name", 0},
+				{tokOp, "==", 4},
+				{tokString, "node", 5},
+				{tokOr, "||", 12},
+				{tokNot, "!", 15},
+				{tokLParen, "(", 16},
+				{tokWord, "user", 17},
+				{tokOp, "!=", 21},
+				{tokWord, "me", 23},
+				{tokRParen, ")", 25},
+			},
+		},
+		{
+			expr: `exe !~ '/usr/*' && cpu<50`,
+			want: []token{
+				{tokWord, "exe", 0},
+				{tokOp, "!~", 4},
+				{tokString, "/usr/*", 7},
+				{tokAnd, "&&", 16},
+				{tokWord, "cpu", 19},
+				{tokOp, "<", 22},
+				{tokWord, "50", 23},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := lex(tt.expr)
+			if err != nil {
+				t.Fatalf("lex(%q): %v", tt.expr, err)
+			}
+			want := append(tt.want, token{tokEOF, "", len(tt.expr)})
+			if !slices.Equal(got, want) {
+				t.Fatalf("lex(%q)\n got %v\nwant %v", tt.expr, got, want)
+			}
+		})
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	info := process.Info{PID: 1, Name: "node"}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`pid==1 || pid==2 && name=="bash"`, true},
+		{`(pid==1 || pid==2) && name=="bash"`, false},
+		{`pid==2 && name=="bash" || name=="node"`, true},
+		{`pid==2 && (name=="bash" || name=="node")`, false},
+		{`!pid==1 || name=="node"`, true},
+		{`!(pid==1 || name=="bash")`, false},
+		{`!!pid==1`, true},
+		{`name~"NO*" && !name~"*js"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			pred, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := pred(info); got != tt.want {
+				t.Fatalf("Parse(%q)(info) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		column int
+		msg    string
+	}{
+		{``, 1, "empty expression"},
+		{`pid=`, 5, "expected value"},
+		{`pid & 1`, 5, `expected "&&"`},
+		{`pid==1 |`, 8, `expected "||"`},
+		{`name=="x`, 7, "unterminated string"},
+		{`(pid==1`, 8, `expected ")"`},
+		{`foo==1`, 1, `unknown field "foo"`},
+		{`pid==1 pid==2`, 8, `unexpected "pid"`},
+		{`pid ~ 1`, 5, "not valid for numeric fields"},
+		{`name < x`, 6, "not valid for text fields"},
+		{`pid==abc`, 6, `invalid number "abc"`},
+		{`age>1y`, 5, `invalid duration "1y"`},
+		{`&& pid==1`, 1, "expected field name"},
+		{`pid==1 ||`, 10, "expected field name, got end of expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Parse(%q) error = %v, want *ParseError", tt.expr, err)
+			}
+			if pe.Pos+1 != tt.column {
+				t.Errorf("Parse(%q) column = %d, want %d", tt.expr, pe.Pos+1, tt.column)
+			}
+			if !strings.Contains(pe.Msg, tt.msg) {
+				t.Errorf("Parse(%q) message = %q, want it to contain %q", tt.expr, pe.Msg, tt.msg)
+			}
+		})
+	}
+}
+
+func TestParseMissingValues(t *testing.T) {
+	known := process.Info{
+		PID:        1,
+		CreateTime: time.Now().Add(-2 * time.Hour),
+		Bindings: []process.Binding{
+			{Proto: process.ProtoTCP, Addr: "0.0.0.0", Port: 80},
+			{Proto: process.ProtoTCP, Addr: "::", Port: 443},
+		},
+	}
+	unknown := process.Info{PID: 2}
+	tests := []struct {
+		expr    string
+		known   bool
+		unknown bool
+	}{
+		{`age>1h`, true, false},
+		{`age<1h`, false, false},
+		{`age>=0s`, true, false},
+		{`age!=1h`, true, true},
+		{`!age>1h`, false, true},
+		{`port<3000`, true, false},
+		{`port<=1024`, true, false},
+		{`port==0`, false, false},
+		{`port==443`, true, false},
+		{`port>80`, true, false},
+		{`port!=80`, false, true},
+		{`port!=8080`, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			pred, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := pred(known); got != tt.known {
+				t.Errorf("Parse(%q)(known) = %v, want %v", tt.expr, got, tt.known)
+			}
+			if got := pred(unknown); got != tt.unknown {
+				t.Errorf("Parse(%q)(unknown) = %v, want %v", tt.expr, got, tt.unknown)
+			}
+		})
+	}
+}
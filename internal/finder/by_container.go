@@ -0,0 +1,45 @@
+package finder
+
+import (
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type containerStrategy struct{}
+
+func (s *containerStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]map[string]string)
+	var result []process.Info
+	for _, info := range all {
+		if info.ContainerID == "" {
+			continue
+		}
+		if matchContainer(info.ContainerID, query.Name) {
+			result = append(result, info)
+			continue
+		}
+		byID, ok := names[info.ContainerRuntime]
+		if !ok {
+			byID = process.ContainerNames(info.ContainerRuntime)
+			names[info.ContainerRuntime] = byID
+		}
+		if name, ok := byID[info.ContainerID]; ok && matchContainer(name, query.Name) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+func matchContainer(value, pattern string) bool {
+	if strings.ContainsAny(pattern, "*?") {
+		return matchGlob(value, pattern)
+	}
+	return strings.HasPrefix(strings.ToLower(value), strings.ToLower(pattern))
+}
@@ -0,0 +1,34 @@
+package finder
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type cwdStrategy struct{}
+
+func (s *cwdStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []process.Info
+	for _, info := range all {
+		if info.Cwd != "" && !info.KernelThread && isWithin(info.Cwd, query.Path) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
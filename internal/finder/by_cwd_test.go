@@ -0,0 +1,31 @@
+package finder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+func TestCwdSkipsKernelThreads(t *testing.T) {
+	provider := &fakeProvider{procs: []process.Info{
+		{PID: 2, Name: "kthreadd", Cwd: "/", KernelThread: true},
+		{PID: 50, PPID: 2, Name: "kworker/0:1", Cwd: "/", KernelThread: true},
+		{PID: 100, Name: "bash", Cwd: "/home/alice"},
+		{PID: 101, Name: "cron", Cwd: "/"},
+		{PID: 102, Name: "unknown"},
+	}}
+
+	procs, err := (&cwdStrategy{}).Find(provider, detect.Query{Type: detect.TypeCwd, Path: "/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int32
+	for _, p := range procs {
+		got = append(got, p.PID)
+	}
+	if want := []int32{100, 101}; !slices.Equal(got, want) {
+		t.Fatalf("cwd:/ matched %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package finder
+
+import (
+	"errors"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type envStrategy struct{}
+
+func (s *envStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result, denied []process.Info
+	for _, info := range all {
+		env, err := provider.Environ(info.PID)
+		if err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				denied = append(denied, info)
+			}
+			continue
+		}
+		if matchEnv(env, query.Key, query.Value) {
+			result = append(result, info)
+		}
+	}
+
+	if len(denied) > 0 {
+		return result, &DeniedError{Reason: "environment not readable", Procs: denied}
+	}
+	return result, nil
+}
+
+func matchEnv(env []string, key, pattern string) bool {
+	for _, kv := range env {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k != key {
+			continue
+		}
+		switch {
+		case pattern == "*":
+			return true
+		case strings.ContainsAny(pattern, "*?["):
+			return matchValueGlob(v, pattern)
+		default:
+			return v == pattern
+		}
+	}
+	return false
+}
+
+func matchValueGlob(value, pattern string) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return false
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
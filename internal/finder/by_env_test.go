@@ -0,0 +1,46 @@
+package finder
+
+import "testing"
+
+func TestMatchEnv(t *testing.T) {
+	env := []string{
+		"HOME=/home/alice",
+		"PATH=/usr/local/bin:/usr/bin",
+		"SERVICE=billing/api",
+		"EMPTY=",
+		"WT=1",
+	}
+	tests := []struct {
+		key     string
+		pattern string
+		want    bool
+	}{
+		{"HOME", "*", true},
+		{"PATH", "*", true},
+		{"SERVICE", "*", true},
+		{"EMPTY", "*", true},
+		{"MISSING", "*", false},
+		{"SERVICE", "billing/api", true},
+		{"SERVICE", "billing", false},
+		{"SERVICE", "billing*", true},
+		{"SERVICE", "billing/*", true},
+		{"SERVICE", "*/api", true},
+		{"SERVICE", "billing/ap?", true},
+		{"SERVICE", "billing/[a-c]pi", true},
+		{"SERVICE", "billing/[!a]pi", false},
+		{"SERVICE", "BILLING*", false},
+		{"SERVICE", "bill.ng*", false},
+		{"SERVICE", "billing/[api", false},
+		{"PATH", "*/usr/bin", true},
+		{"EMPTY", "", true},
+		{"WT", "", false},
+		{"HOME=/home", "*", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.pattern, func(t *testing.T) {
+			if got := matchEnv(env, tt.key, tt.pattern); got != tt.want {
+				t.Fatalf("matchEnv(%q, %q) = %v, want %v", tt.key, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
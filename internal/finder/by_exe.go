@@ -0,0 +1,37 @@
+package finder
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type exeStrategy struct{}
+
+func (s *exeStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []process.Info
+	for _, info := range all {
+		if info.Exe != "" && matchExe(info.Exe, query.Path) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+func matchExe(exe, pattern string) bool {
+	value := exe
+	if !strings.ContainsRune(pattern, filepath.Separator) {
+		value = filepath.Base(exe)
+	}
+	if strings.ContainsAny(pattern, "*?") {
+		return matchGlob(value, pattern)
+	}
+	return filepath.Clean(value) == filepath.Clean(pattern)
+}
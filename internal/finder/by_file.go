@@ -0,0 +1,14 @@
+package finder
+
+import (
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type fileStrategy struct {
+	mount bool
+}
+
+func (s *fileStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	return provider.FindByFile(query.Path, s.mount)
+}
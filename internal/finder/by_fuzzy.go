@@ -0,0 +1,50 @@
+package finder
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/fuzzy"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type fuzzyStrategy struct{}
+
+func (s *fuzzyStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		info  process.Info
+		score float64
+	}
+	var matches []scored
+	for _, info := range all {
+		score := fuzzy.Score(query.Name, info.Name)
+		if info.Exe != "" {
+			score = max(score, fuzzy.Score(query.Name, filepath.Base(info.Exe)))
+		}
+		if score >= fuzzy.Threshold {
+			matches = append(matches, scored{info: info, score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	result := make([]process.Info, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.info)
+	}
+	return result, nil
+}
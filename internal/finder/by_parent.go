@@ -0,0 +1,61 @@
+package finder
+
+import (
+	"slices"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type childrenStrategy struct {
+	finder *Finder
+}
+
+func (s *childrenStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	parents, err := s.finder.Find(*query.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if len(parents) == 0 {
+		return nil, nil
+	}
+
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	byParent := make(map[int32][]process.Info)
+	for _, info := range all {
+		byParent[info.PPID] = append(byParent[info.PPID], info)
+	}
+
+	seen := make(map[int32]bool)
+	var result []process.Info
+	queue := make([]int32, 0, len(parents))
+	for _, p := range parents {
+		queue = append(queue, p.PID)
+	}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		for _, child := range byParent[pid] {
+			if seen[child.PID] || child.PID == pid {
+				continue
+			}
+			seen[child.PID] = true
+			result = append(result, child)
+			if query.Recursive {
+				queue = append(queue, child.PID)
+			}
+		}
+	}
+
+	parentPIDs := make([]int32, len(parents))
+	for i, p := range parents {
+		parentPIDs[i] = p.PID
+	}
+	return slices.DeleteFunc(result, func(info process.Info) bool {
+		return slices.Contains(parentPIDs, info.PID)
+	}), nil
+}
@@ -1,6 +1,12 @@
 package finder
 
 import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
 	"github.com/aiomayo/hdf/internal/detect"
 	"github.com/aiomayo/hdf/internal/process"
 )
@@ -8,5 +14,131 @@ import (
 type portStrategy struct{}
 
 func (s *portStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
-	return provider.FindByPort(query.Port)
+	if query.Proto == "" && query.State == "" {
+		return provider.FindByPort(query.Port)
+	}
+	match := socketMatcher(query)
+	return provider.FindBySocket(func(sock process.Socket) bool {
+		return sock.Port == query.Port && match(sock)
+	})
+}
+
+type hostPortStrategy struct{}
+
+func (s *hostPortStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	if query.Host == "" {
+		return (&portStrategy{}).Find(provider, query)
+	}
+
+	matchHost, err := hostMatcher(query.Host)
+	if err != nil {
+		return nil, err
+	}
+	match := socketMatcher(query)
+	return provider.FindBySocket(func(sock process.Socket) bool {
+		return sock.Port == query.Port && matchHost(sock.IP) && match(sock)
+	})
+}
+
+type remoteStrategy struct{}
+
+func (s *remoteStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	matchHost := func(string) bool { return true }
+	if query.Host != "" {
+		var err error
+		matchHost, err = hostMatcher(query.Host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	match := socketMatcher(query)
+	return provider.FindBySocket(func(sock process.Socket) bool {
+		return sock.RemotePort == query.Port && matchHost(sock.RemoteIP) && match(sock)
+	})
+}
+
+func socketMatcher(query detect.Query) func(process.Socket) bool {
+	return func(sock process.Socket) bool {
+		if query.Proto != "" && sock.Proto != query.Proto {
+			return false
+		}
+		switch {
+		case query.State == "" && query.Type == detect.TypeRemote:
+			return !sock.Listening()
+		case query.State == "":
+			return sock.Listening()
+		case strings.EqualFold(query.State, "any"):
+			return true
+		default:
+			return strings.EqualFold(sock.Status, query.State)
+		}
+	}
+}
+
+func hostMatcher(host string) (func(ip string) bool, error) {
+	switch strings.ToLower(host) {
+	case "*":
+		return isWildcardIP, nil
+	case "localhost":
+		return func(ip string) bool {
+			parsed := net.ParseIP(ip)
+			return parsed != nil && parsed.IsLoopback()
+		}, nil
+	}
+
+	var addrs []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		addrs = append(addrs, ip)
+	} else {
+		resolved, err := net.LookupIP(host)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve host %q: %w", host, err)
+		}
+		addrs = resolved
+	}
+
+	return func(ip string) bool {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return false
+		}
+		for _, addr := range addrs {
+			if addr.Equal(parsed) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func isWildcardIP(ip string) bool {
+	if ip == "*" || ip == "" {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.IsUnspecified()
+}
+
+type portRangeStrategy struct{}
+
+func (s *portRangeStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	match := socketMatcher(query)
+	result, err := provider.FindBySocket(func(sock process.Socket) bool {
+		return sock.Port >= query.Port && sock.Port <= query.PortEnd && match(sock)
+	})
+	if err != nil {
+		return nil, err
+	}
+	firstInRange := func(p process.Info) uint32 {
+		for _, port := range p.Ports() {
+			if port >= query.Port && port <= query.PortEnd {
+				return port
+			}
+		}
+		return query.PortEnd
+	}
+	slices.SortStableFunc(result, func(a, b process.Info) int {
+		return cmp.Or(cmp.Compare(firstInRange(a), firstInRange(b)), cmp.Compare(a.PID, b.PID))
+	})
+	return result, nil
 }
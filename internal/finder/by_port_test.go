@@ -0,0 +1,117 @@
+package finder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type socketProvider struct {
+	process.Provider
+	sockets []process.Socket
+}
+
+func (p *socketProvider) FindByPort(port uint32) ([]process.Info, error) {
+	return p.FindBySocket(func(s process.Socket) bool {
+		return s.Port == port && s.Listening()
+	})
+}
+
+func (p *socketProvider) FindBySocket(match func(process.Socket) bool) ([]process.Info, error) {
+	var pids []int32
+	for _, s := range p.sockets {
+		if match(s) && !slices.Contains(pids, s.PID) {
+			pids = append(pids, s.PID)
+		}
+	}
+	slices.Sort(pids)
+	var result []process.Info
+	for _, pid := range pids {
+		result = append(result, process.Info{PID: pid})
+	}
+	return result, nil
+}
+
+func TestSocketQueries(t *testing.T) {
+	provider := &socketProvider{sockets: []process.Socket{
+		{PID: 1, Proto: process.ProtoTCP, IP: "0.0.0.0", Port: 3000, RemoteIP: "0.0.0.0", Status: "LISTEN"},
+		{PID: 2, Proto: process.ProtoTCP, IP: "127.0.0.1", Port: 3001, RemoteIP: "0.0.0.0", Status: "LISTEN"},
+		{PID: 3, Proto: process.ProtoTCP, IP: "::1", Port: 3002, RemoteIP: "::", Status: "LISTEN"},
+		{PID: 4, Proto: process.ProtoTCP, IP: "::", Port: 3003, RemoteIP: "::", Status: "LISTEN"},
+		{PID: 5, Proto: process.ProtoTCP, IP: "::ffff:127.0.0.1", Port: 3004, RemoteIP: "::", Status: "LISTEN"},
+		{PID: 6, Proto: process.ProtoTCP, IP: "192.168.1.10", Port: 3005, RemoteIP: "0.0.0.0", Status: "LISTEN"},
+		{PID: 10, Proto: process.ProtoTCP, IP: "192.168.1.10", Port: 3005, RemoteIP: "10.0.0.20", RemotePort: 40000, Status: "ESTABLISHED"},
+		{PID: 7, Proto: process.ProtoUDP, IP: "0.0.0.0", Port: 53, RemoteIP: "0.0.0.0", Status: "NONE"},
+		{PID: 11, Proto: process.ProtoUDP, IP: "::", Port: 5353, RemoteIP: "::", Status: "NONE"},
+		{PID: 12, Proto: process.ProtoUDP, IP: "10.0.0.5", Port: 40001, RemoteIP: "10.0.0.53", RemotePort: 53, Status: "NONE"},
+		{PID: 8, Proto: process.ProtoTCP, IP: "10.0.0.5", Port: 51000, RemoteIP: "10.0.0.9", RemotePort: 5432, Status: "ESTABLISHED"},
+		{PID: 9, Proto: process.ProtoTCP, IP: "10.0.0.5", Port: 51001, RemoteIP: "10.0.0.10", RemotePort: 5432, Status: "TIME_WAIT"},
+		{PID: 13, Proto: process.ProtoTCP, IP: "::ffff:10.0.0.5", Port: 51002, RemoteIP: "::ffff:127.0.0.1", RemotePort: 6379, Status: "ESTABLISHED"},
+	}}
+
+	tests := []struct {
+		input string
+		state string
+		want  []int32
+	}{
+		{input: "3000", want: []int32{1}},
+		{input: "*:3000", want: []int32{1}},
+		{input: "0.0.0.0:3000", want: []int32{1}},
+		{input: "*:3001"},
+		{input: "*:3003", want: []int32{4}},
+		{input: "[::]:3003", want: []int32{4}},
+		{input: "0.0.0.0:3003"},
+		{input: "127.0.0.1:3001", want: []int32{2}},
+		{input: "[::ffff:127.0.0.1]:3001", want: []int32{2}},
+		{input: "localhost:3001", want: []int32{2}},
+		{input: "LOCALHOST:3002", want: []int32{3}},
+		{input: "[::1]:3002", want: []int32{3}},
+		{input: "127.0.0.1:3002"},
+		{input: "localhost:3004", want: []int32{5}},
+		{input: "127.0.0.1:3004", want: []int32{5}},
+		{input: "localhost:3005"},
+		{input: "192.168.1.10:3005", want: []int32{6}},
+		{input: "192.168.1.10:3005", state: "ESTABLISHED", want: []int32{10}},
+		{input: "3005", state: "ANY", want: []int32{6, 10}},
+		{input: "3000-3003", want: []int32{1, 2, 3, 4}},
+		{input: "53", want: []int32{7}},
+		{input: "udp:53", want: []int32{7}},
+		{input: "tcp:53"},
+		{input: "udp:[::]:5353", want: []int32{11}},
+		{input: "udp:*:5353", want: []int32{11}},
+		{input: "53", state: "NONE", want: []int32{7}},
+		{input: "53", state: "LISTEN"},
+		{input: "40001"},
+		{input: "udp:40001", state: "NONE", want: []int32{12}},
+		{input: "remote:53", want: []int32{12}},
+		{input: "remote:5432", want: []int32{8, 9}},
+		{input: "remote:10.0.0.9:5432", want: []int32{8}},
+		{input: "remote:5432", state: "ESTABLISHED", want: []int32{8}},
+		{input: "remote:5432", state: "TIME_WAIT", want: []int32{9}},
+		{input: "remote:localhost:5432"},
+		{input: "remote:localhost:6379", want: []int32{13}},
+		{input: "remote:127.0.0.1:6379", want: []int32{13}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input+"/"+tt.state, func(t *testing.T) {
+			q, err := detect.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			q.State = tt.state
+			procs, err := New(provider).Find(q)
+			if err != nil {
+				t.Fatalf("Find(%q): %v", tt.input, err)
+			}
+			var got []int32
+			for _, p := range procs {
+				got = append(got, p.PID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Find(%q, state %q) = %v, want %v", tt.input, tt.state, got, tt.want)
+			}
+		})
+	}
+}
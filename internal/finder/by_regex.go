@@ -0,0 +1,44 @@
+package finder
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type regexStrategy struct{}
+
+func (s *regexStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	re, err := regexp.Compile(query.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", query.Name, err)
+	}
+
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []process.Info
+	for _, info := range all {
+		if matchRegex(info, re, query.Field) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+func matchRegex(info process.Info, re *regexp.Regexp, field string) bool {
+	switch field {
+	case detect.FieldName:
+		return re.MatchString(info.Name)
+	case detect.FieldCmdline:
+		return re.MatchString(info.Cmdline)
+	case detect.FieldExe:
+		return info.Exe != "" && re.MatchString(info.Exe)
+	default:
+		return re.MatchString(info.Name) || re.MatchString(info.Cmdline)
+	}
+}
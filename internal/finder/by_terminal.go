@@ -0,0 +1,39 @@
+package finder
+
+import (
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type terminalStrategy struct {
+	match func(info process.Info, query detect.Query) bool
+}
+
+func (s *terminalStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []process.Info
+	for _, info := range all {
+		if s.match(info, query) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+func matchTTY(info process.Info, query detect.Query) bool {
+	return info.TTY != "" && strings.EqualFold(info.TTY, query.Name)
+}
+
+func matchSession(info process.Info, query detect.Query) bool {
+	return info.SID == query.PID
+}
+
+func matchPGID(info process.Info, query detect.Query) bool {
+	return info.PGID == query.PID
+}
@@ -0,0 +1,29 @@
+package finder
+
+import (
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type unitStrategy struct{}
+
+func (s *unitStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	all, err := provider.List()
+	if err != nil {
+		return nil, err
+	}
+
+	glob := strings.ContainsAny(query.Name, "*?")
+	var result []process.Info
+	for _, info := range all {
+		if info.Unit == "" {
+			continue
+		}
+		if (glob && matchGlob(info.Unit, query.Name)) || strings.EqualFold(info.Unit, query.Name) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,12 @@
+package finder
+
+import (
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type unixStrategy struct{}
+
+func (s *unixStrategy) Find(provider process.Provider, query detect.Query) ([]process.Info, error) {
+	return provider.FindByUnixSocket(query.Path)
+}
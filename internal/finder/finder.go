@@ -1,7 +1,9 @@
 package finder
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/aiomayo/hdf/internal/detect"
 	"github.com/aiomayo/hdf/internal/process"
@@ -11,22 +13,49 @@ type strategy interface {
 	Find(provider process.Provider, query detect.Query) ([]process.Info, error)
 }
 
+type DeniedError struct {
+	Reason string
+	Procs  []process.Info
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("%s for %d process(es)", e.Reason, len(e.Procs))
+}
+
 type Finder struct {
 	provider   process.Provider
 	strategies map[detect.QueryType]strategy
 }
 
 func New(provider process.Provider) *Finder {
-	return &Finder{
+	f := &Finder{
 		provider: provider,
 		strategies: map[detect.QueryType]strategy{
-			detect.TypePID:      &pidStrategy{},
-			detect.TypePort:     &portStrategy{},
-			detect.TypeHostPort: &portStrategy{},
-			detect.TypeName:     &nameStrategy{},
-			detect.TypeGlob:     &nameStrategy{},
+			detect.TypePID:       &pidStrategy{},
+			detect.TypePort:      &portStrategy{},
+			detect.TypeHostPort:  &hostPortStrategy{},
+			detect.TypeName:      &nameStrategy{},
+			detect.TypeGlob:      &nameStrategy{},
+			detect.TypePortRange: &portRangeStrategy{},
+			detect.TypeRegex:     &regexStrategy{},
+			detect.TypeUser:      &userStrategy{},
+			detect.TypeExe:       &exeStrategy{},
+			detect.TypeCwd:       &cwdStrategy{},
+			detect.TypeUnix:      &unixStrategy{},
+			detect.TypeFile:      &fileStrategy{},
+			detect.TypeMount:     &fileStrategy{mount: true},
+			detect.TypeEnv:       &envStrategy{},
+			detect.TypeContainer: &containerStrategy{},
+			detect.TypeUnit:      &unitStrategy{},
+			detect.TypeTTY:       &terminalStrategy{match: matchTTY},
+			detect.TypeSession:   &terminalStrategy{match: matchSession},
+			detect.TypePGID:      &terminalStrategy{match: matchPGID},
+			detect.TypeFuzzy:     &fuzzyStrategy{},
+			detect.TypeRemote:    &remoteStrategy{},
 		},
 	}
+	f.strategies[detect.TypeChildren] = &childrenStrategy{finder: f}
+	return f
 }
 
 func (f *Finder) Find(query detect.Query) ([]process.Info, error) {
@@ -37,6 +66,47 @@ func (f *Finder) Find(query detect.Query) ([]process.Info, error) {
 	return s.Find(f.provider, query)
 }
 
+func (f *Finder) FindAll(queries []detect.Query) ([]process.Info, error) {
+	seen := make(map[int32]bool)
+	var result []process.Info
+	var denied *DeniedError
+	for _, q := range queries {
+		procs, err := f.Find(q)
+		var de *DeniedError
+		switch {
+		case errors.As(err, &de):
+			denied = mergeDenied(denied, de)
+		case errors.Is(err, process.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", q.Raw, err)
+		}
+		for _, p := range procs {
+			if seen[p.PID] {
+				continue
+			}
+			seen[p.PID] = true
+			result = append(result, p)
+		}
+	}
+	if denied != nil {
+		return result, denied
+	}
+	return result, nil
+}
+
+func mergeDenied(acc, de *DeniedError) *DeniedError {
+	if acc == nil {
+		return &DeniedError{Reason: de.Reason, Procs: slices.Clone(de.Procs)}
+	}
+	for _, p := range de.Procs {
+		if !slices.ContainsFunc(acc.Procs, func(q process.Info) bool { return q.PID == p.PID }) {
+			acc.Procs = append(acc.Procs, p)
+		}
+	}
+	return acc
+}
+
 func (f *Finder) FindByUser(username string) ([]process.Info, error) {
 	s := &userStrategy{}
 	return s.Find(f.provider, detect.Query{Name: username})
@@ -0,0 +1,69 @@
+package finder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/detect"
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type fakeProvider struct {
+	process.Provider
+	procs []process.Info
+}
+
+func (p *fakeProvider) FindByPID(pid int32) (*process.Info, error) {
+	for _, info := range p.procs {
+		if info.PID == pid {
+			return &info, nil
+		}
+	}
+	return nil, process.ErrNotFound
+}
+
+func (p *fakeProvider) List() ([]process.Info, error) {
+	return p.procs, nil
+}
+
+func TestFindAllSkipsMissingProcesses(t *testing.T) {
+	f := New(&fakeProvider{procs: []process.Info{
+		{PID: 10, Name: "node"},
+		{PID: 20, Name: "redis-server"},
+	}})
+
+	tests := []struct {
+		name    string
+		queries []detect.Query
+		want    []int32
+	}{
+		{
+			name:    "missing pid alone",
+			queries: []detect.Query{{Type: detect.TypePID, PID: 999999, Raw: "pid:999999"}},
+		},
+		{
+			name: "missing pid in a union",
+			queries: []detect.Query{
+				{Type: detect.TypeName, Name: "node", Raw: "node"},
+				{Type: detect.TypePID, PID: 999999, Raw: "pid:999999"},
+				{Type: detect.TypePID, PID: 20, Raw: "pid:20"},
+			},
+			want: []int32{10, 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			procs, err := f.FindAll(tt.queries)
+			if err != nil {
+				t.Fatalf("FindAll: %v", err)
+			}
+			var got []int32
+			for _, p := range procs {
+				got = append(got, p.PID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("FindAll PIDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+const Threshold = 0.6
+
+type Match struct {
+	Value string
+	Score float64
+}
+
+func Score(query, candidate string) float64 {
+	q := strings.ToLower(query)
+	c := strings.ToLower(candidate)
+	if q == "" || c == "" {
+		return 0
+	}
+	if q == c {
+		return 1
+	}
+
+	longest := max(len(q), len(c))
+	score := 1 - float64(distance(q, c))/float64(longest)
+	if strings.Contains(c, q) {
+		contained := 0.8 + 0.2*float64(len(q))/float64(len(c))
+		if strings.HasPrefix(c, q) {
+			contained += 0.05
+		}
+		score = max(score, min(contained, 0.99))
+	}
+	return max(score, 0)
+}
+
+func Rank(query string, candidates []string, threshold float64) []Match {
+	seen := make(map[string]bool)
+	var matches []Match
+	for _, c := range candidates {
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		if score := Score(query, c); score >= threshold {
+			matches = append(matches, Match{Value: c, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Value < matches[j].Value
+	})
+	return matches
+}
+
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,69 @@
+package fuzzy
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		query     string
+		candidate string
+		want      float64
+	}{
+		{"node", "node", 1},
+		{"NODE", "node", 1},
+		{"postgress", "postgres", 1 - 1.0/9},
+		{"ngnix", "nginx", 0.6},
+		{"serv", "server", 0.8 + 0.2*4/6 + 0.05},
+		{"ver", "server", 0.8 + 0.2*3/6},
+		{"python3", "python3.11", 0.99},
+		{"redis", "nginx", 0},
+		{"", "node", 0},
+		{"node", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.candidate, func(t *testing.T) {
+			if got := Score(tt.query, tt.candidate); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("Score(%q, %q) = %v, want %v", tt.query, tt.candidate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	tests := []struct {
+		query     string
+		candidate string
+		match     bool
+	}{
+		{"postgress", "postgres", true},
+		{"ngnix", "nginx", true},
+		{"chrome", "chromium", true},
+		{"py", "python3.11", true},
+		{"redis", "nginx", false},
+		{"node", "bash", false},
+		{"java", "javascript-language-server", true},
+		{"sshd", "systemd", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.candidate, func(t *testing.T) {
+			score := Score(tt.query, tt.candidate)
+			if got := score >= Threshold; got != tt.match {
+				t.Fatalf("Score(%q, %q) = %.2f, match = %v, want %v", tt.query, tt.candidate, score, got, tt.match)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	candidates := []string{"nginx", "postgresql", "postgres", "postgres", "", "redis"}
+	var got []string
+	for _, m := range Rank("postgress", candidates, 0.5) {
+		got = append(got, m.Value)
+	}
+	if want := []string{"postgres", "postgresql"}; !slices.Equal(got, want) {
+		t.Fatalf("Rank = %v, want %v", got, want)
+	}
+}
@@ -9,15 +9,19 @@ import (
 )
 
 type Options struct {
-	Action  Action
-	Tree    bool
-	Timeout time.Duration
-	DryRun  bool
+	Action    Action
+	Tree      bool
+	Timeout   time.Duration
+	Signal    process.Signal
+	DryRun    bool
+	StopUnits bool
 }
 
 type Result struct {
 	PID     int32
 	Name    string
+	Unit    string
+	Signal  string
 	Success bool
 	Error   error
 	DryRun  bool
@@ -35,21 +39,62 @@ func (k *Killer) Execute(targets []process.Info, opts Options) []Result {
 	if opts.Tree {
 		targets = k.expandTree(targets)
 	}
+	targets = skipReapedZombies(targets)
 
 	var results []Result
+	stopped := make(map[string]error)
 	for _, target := range targets {
+		if opts.StopUnits && StoppableUnit(target) {
+			results = append(results, k.stopUnitOf(target, opts, stopped))
+			continue
+		}
 		r := k.killOne(target, opts)
 		results = append(results, r)
 	}
 	return results
 }
 
+func (k *Killer) stopUnitOf(target process.Info, opts Options, stopped map[string]error) Result {
+	r := Result{
+		PID:    target.PID,
+		Name:   target.Name,
+		Unit:   target.Unit,
+		DryRun: opts.DryRun,
+	}
+
+	if opts.DryRun {
+		r.Success = true
+		return r
+	}
+
+	key := target.UnitOwner + "/" + target.Unit
+	err, done := stopped[key]
+	if !done {
+		err = stopUnit(target.Unit, target.UnitOwner)
+		stopped[key] = err
+	}
+	if err != nil {
+		r.Error = err
+		return r
+	}
+	r.Success = true
+	return r
+}
+
 func (k *Killer) killOne(target process.Info, opts Options) Result {
 	r := Result{
 		PID:    target.PID,
 		Name:   target.Name,
 		DryRun: opts.DryRun,
 	}
+	if opts.Action == ActionSignal {
+		r.Signal = opts.Signal.Name()
+	}
+
+	if target.State == process.StateZombie {
+		r.Error = k.zombieError(target)
+		return r
+	}
 
 	if opts.DryRun {
 		r.Success = true
@@ -61,9 +106,14 @@ func (k *Killer) killOne(target process.Info, opts Options) Result {
 	case ActionKill:
 		err = k.provider.Kill(target.PID)
 	case ActionTerminate:
-		err = k.provider.Terminate(target.PID)
+		err = k.terminate(target)
 	case ActionGraceful:
-		err = k.graceful(target.PID, opts.Timeout)
+		err = k.graceful(target, opts.Timeout)
+	case ActionSignal:
+		err = k.provider.Signal(target.PID, opts.Signal)
+	}
+	if err == nil && target.State == process.StateDiskWait {
+		err = k.awaitUninterruptible(target.PID)
 	}
 
 	if err != nil {
@@ -74,8 +124,19 @@ func (k *Killer) killOne(target process.Info, opts Options) Result {
 	return r
 }
 
-func (k *Killer) graceful(pid int32, timeout time.Duration) error {
-	if err := k.provider.Terminate(pid); err != nil {
+func (k *Killer) terminate(target process.Info) error {
+	if err := k.provider.Terminate(target.PID); err != nil {
+		return err
+	}
+	if target.State == process.StateStopped {
+		return k.provider.Resume(target.PID)
+	}
+	return nil
+}
+
+func (k *Killer) graceful(target process.Info, timeout time.Duration) error {
+	pid := target.PID
+	if err := k.terminate(target); err != nil {
 		return err
 	}
 
@@ -100,33 +161,58 @@ func (k *Killer) expandTree(targets []process.Info) []process.Info {
 	var expanded []process.Info
 
 	for _, target := range targets {
-		k.collectTree(target.PID, &expanded, seen)
+		k.collectTree(target.PID, true, &expanded, seen)
 	}
 
 	slices.Reverse(expanded)
 	return expanded
 }
 
-func (k *Killer) collectTree(pid int32, result *[]process.Info, seen map[int32]bool) {
+func (k *Killer) collectTree(pid int32, root bool, result *[]process.Info, seen map[int32]bool) {
 	if seen[pid] {
 		return
 	}
 	seen[pid] = true
 
 	info, err := k.provider.FindByPID(pid)
-	if err != nil {
+	if err != nil || (!root && info.State == process.StateZombie) {
 		return
 	}
 
 	children, _ := k.provider.Children(pid)
 	for _, child := range children {
-		k.collectTree(child.PID, result, seen)
+		k.collectTree(child.PID, false, result, seen)
 	}
 
 	*result = append(*result, *info)
 }
 
 func FormatResult(r Result) string {
+	if r.Unit != "" {
+		switch {
+		case r.DryRun:
+			return fmt.Sprintf("[dry-run] would stop unit %s (%s, PID %d)", r.Unit, r.Name, r.PID)
+		case r.Success:
+			return fmt.Sprintf("stopped unit %s (%s, PID %d)", r.Unit, r.Name, r.PID)
+		default:
+			return fmt.Sprintf("failed to stop unit %s (%s, PID %d): %v", r.Unit, r.Name, r.PID, r.Error)
+		}
+	}
+	if r.Signal != "" {
+		switch {
+		case r.DryRun && r.Error != nil:
+			return fmt.Sprintf("[dry-run] could not send %s to %s (PID %d): %v", r.Signal, r.Name, r.PID, r.Error)
+		case r.DryRun:
+			return fmt.Sprintf("[dry-run] would send %s to %s (PID %d)", r.Signal, r.Name, r.PID)
+		case r.Success:
+			return fmt.Sprintf("sent %s to %s (PID %d)", r.Signal, r.Name, r.PID)
+		default:
+			return fmt.Sprintf("failed to send %s to %s (PID %d): %v", r.Signal, r.Name, r.PID, r.Error)
+		}
+	}
+	if r.DryRun && r.Error != nil {
+		return fmt.Sprintf("[dry-run] could not kill %s (PID %d): %v", r.Name, r.PID, r.Error)
+	}
 	if r.DryRun {
 		return fmt.Sprintf("[dry-run] would kill %s (PID %d)", r.Name, r.PID)
 	}
@@ -0,0 +1,149 @@
+package killer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+type fakeProvider struct {
+	process.Provider
+	procs  []process.Info
+	killed []int32
+}
+
+func (p *fakeProvider) FindByPID(pid int32) (*process.Info, error) {
+	for _, info := range p.procs {
+		if info.PID == pid {
+			return &info, nil
+		}
+	}
+	return nil, process.ErrNotFound
+}
+
+func (p *fakeProvider) Children(pid int32) ([]process.Info, error) {
+	var children []process.Info
+	for _, info := range p.procs {
+		if info.PPID == pid {
+			children = append(children, info)
+		}
+	}
+	return children, nil
+}
+
+func (p *fakeProvider) Kill(pid int32) error {
+	p.killed = append(p.killed, pid)
+	return nil
+}
+
+func TestExecuteZombies(t *testing.T) {
+	procs := []process.Info{
+		{PID: 1, Name: "init"},
+		{PID: 10, PPID: 1, Name: "server"},
+		{PID: 11, PPID: 10, Name: "worker"},
+		{PID: 12, PPID: 10, Name: "worker", State: process.StateZombie},
+		{PID: 20, PPID: 1, Name: "orphaned", State: process.StateZombie},
+	}
+	find := func(pids ...int32) []process.Info {
+		var result []process.Info
+		for _, p := range procs {
+			if slices.Contains(pids, p.PID) {
+				result = append(result, p)
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		targets []process.Info
+		opts    Options
+		results []int32
+		failed  []int32
+		killed  []int32
+	}{
+		{
+			name:    "tree skips zombie children",
+			targets: find(10),
+			opts:    Options{Action: ActionKill, Tree: true},
+			results: []int32{10, 11},
+			killed:  []int32{10, 11},
+		},
+		{
+			name:    "zombie with targeted parent",
+			targets: find(10, 12),
+			opts:    Options{Action: ActionKill},
+			results: []int32{10},
+			killed:  []int32{10},
+		},
+		{
+			name:    "dry run tree",
+			targets: find(10),
+			opts:    Options{Action: ActionKill, Tree: true, DryRun: true},
+			results: []int32{10, 11},
+		},
+		{
+			name:    "zombie alone",
+			targets: find(20),
+			opts:    Options{Action: ActionKill},
+			results: []int32{20},
+			failed:  []int32{20},
+		},
+		{
+			name:    "dry run zombie alone",
+			targets: find(20),
+			opts:    Options{Action: ActionKill, DryRun: true},
+			results: []int32{20},
+			failed:  []int32{20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{procs: procs}
+			results := New(provider).Execute(tt.targets, tt.opts)
+
+			var got, failed []int32
+			for _, r := range results {
+				got = append(got, r.PID)
+				if !r.Success {
+					failed = append(failed, r.PID)
+				}
+			}
+			if !slices.Equal(got, tt.results) {
+				t.Errorf("results for %v, want %v", got, tt.results)
+			}
+			if !slices.Equal(failed, tt.failed) {
+				t.Errorf("failed %v, want %v", failed, tt.failed)
+			}
+			if !slices.Equal(provider.killed, tt.killed) {
+				t.Errorf("killed %v, want %v", provider.killed, tt.killed)
+			}
+		})
+	}
+}
+
+func TestFormatResultDryRunFailure(t *testing.T) {
+	tests := []struct {
+		result Result
+		want   string
+	}{
+		{
+			Result{PID: 20, Name: "orphaned", DryRun: true, Error: ErrZombie},
+			"[dry-run] could not kill orphaned (PID 20): process is a zombie",
+		},
+		{
+			Result{PID: 20, Name: "orphaned", Signal: "HUP", DryRun: true, Error: ErrZombie},
+			"[dry-run] could not send HUP to orphaned (PID 20): process is a zombie",
+		},
+		{
+			Result{PID: 10, Name: "server", DryRun: true, Success: true},
+			"[dry-run] would kill server (PID 10)",
+		},
+	}
+	for _, tt := range tests {
+		if got := FormatResult(tt.result); got != tt.want {
+			t.Errorf("FormatResult(%+v) = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ const (
 	ActionTerminate Action = iota
 	ActionKill
 	ActionGraceful
+	ActionSignal
 )
 
 func (a Action) String() string {
@@ -16,6 +17,8 @@ func (a Action) String() string {
 		return "kill"
 	case ActionGraceful:
 		return "graceful"
+	case ActionSignal:
+		return "signal"
 	default:
 		return "unknown"
 	}
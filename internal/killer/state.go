@@ -0,0 +1,52 @@
+package killer
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+var (
+	ErrZombie          = errors.New("process is a zombie")
+	ErrUninterruptible = errors.New("process is in uninterruptible sleep")
+)
+
+func skipReapedZombies(targets []process.Info) []process.Info {
+	pids := make(map[int32]bool, len(targets))
+	for _, t := range targets {
+		pids[t.PID] = true
+	}
+	return slices.DeleteFunc(slices.Clone(targets), func(t process.Info) bool {
+		return t.State == process.StateZombie && pids[t.PPID]
+	})
+}
+
+func (k *Killer) zombieError(target process.Info) error {
+	parent, err := k.provider.FindByPID(target.PPID)
+	if err != nil {
+		return fmt.Errorf("%w — parent PID %d has not reaped it", ErrZombie, target.PPID)
+	}
+	return fmt.Errorf("%w — parent %s (PID %d) has not reaped it", ErrZombie, parent.Name, parent.PID)
+}
+
+func (k *Killer) awaitUninterruptible(pid int32) error {
+	for range 5 {
+		if !k.provider.IsRunning(pid) {
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	info, err := k.provider.FindByPID(pid)
+	if err != nil || info.State != process.StateDiskWait {
+		return nil
+	}
+	wchan := info.WChan
+	if wchan == "" {
+		wchan = "the kernel"
+	}
+	return fmt.Errorf("%w in %s — the signal stays pending until it returns", ErrUninterruptible, wchan)
+}
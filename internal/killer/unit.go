@@ -0,0 +1,46 @@
+package killer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+func StoppableUnit(info process.Info) bool {
+	return strings.HasSuffix(info.Unit, ".service") && !strings.HasPrefix(info.Unit, "user@")
+}
+
+func Units(targets []process.Info) []string {
+	seen := make(map[string]bool)
+	var units []string
+	for _, t := range targets {
+		if StoppableUnit(t) && !seen[t.Unit] {
+			seen[t.Unit] = true
+			units = append(units, t.Unit)
+		}
+	}
+	return units
+}
+
+func stopUnit(unit, owner string) error {
+	args := []string{"stop", unit}
+	switch owner {
+	case "":
+	case strconv.Itoa(os.Getuid()):
+		args = append([]string{"--user"}, args...)
+	default:
+		args = append([]string{"--machine=" + owner + "@", "--user"}, args...)
+	}
+	out, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("systemctl %s: %s", strings.Join(args, " "), msg)
+		}
+		return fmt.Errorf("systemctl %s: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
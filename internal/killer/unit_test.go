@@ -0,0 +1,137 @@
+//go:build !windows
+
+package killer
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+const systemctlStub = `#!/bin/sh
+echo "$@" >> "$SYSTEMCTL_LOG"
+case "$*" in
+*broken.service*)
+	echo "Failed to stop broken.service: Unit broken.service not loaded." >&2
+	exit 5
+	;;
+esac
+`
+
+func stubSystemctl(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "systemctl"), []byte(systemctlStub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logPath := filepath.Join(dir, "calls.log")
+	t.Setenv("PATH", dir)
+	t.Setenv("SYSTEMCTL_LOG", logPath)
+	return logPath
+}
+
+func systemctlCalls(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestStopUnit(t *testing.T) {
+	self := strconv.Itoa(os.Getuid())
+	other := strconv.Itoa(os.Getuid() + 1)
+	tests := []struct {
+		name    string
+		unit    string
+		owner   string
+		call    string
+		wantErr string
+	}{
+		{name: "system", unit: "nginx.service", call: "stop nginx.service"},
+		{name: "own user", unit: "syncthing.service", owner: self, call: "--user stop syncthing.service"},
+		{name: "other user", unit: "syncthing.service", owner: other, call: "--machine=" + other + "@ --user stop syncthing.service"},
+		{name: "failure", unit: "broken.service", call: "stop broken.service", wantErr: "systemctl stop broken.service: Failed to stop broken.service"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPath := stubSystemctl(t)
+			err := stopUnit(tt.unit, tt.owner)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("stopUnit(%q): %v", tt.unit, err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("stopUnit(%q) error = %v, want %q", tt.unit, err, tt.wantErr)
+			}
+			if calls := systemctlCalls(t, logPath); !slices.Equal(calls, []string{tt.call}) {
+				t.Fatalf("systemctl calls = %q, want %q", calls, tt.call)
+			}
+		})
+	}
+}
+
+func TestExecuteStopUnits(t *testing.T) {
+	self := strconv.Itoa(os.Getuid())
+	other := strconv.Itoa(os.Getuid() + 1)
+	targets := []process.Info{
+		{PID: 10, Name: "nginx", Unit: "nginx.service"},
+		{PID: 11, Name: "nginx", Unit: "nginx.service"},
+		{PID: 20, Name: "syncthing", Unit: "syncthing.service", UnitOwner: self},
+		{PID: 21, Name: "syncthing", Unit: "syncthing.service", UnitOwner: other},
+		{PID: 30, Name: "bash", Unit: "session-2.scope"},
+		{PID: 40, Name: "worker", Unit: "broken.service"},
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		logPath := stubSystemctl(t)
+		provider := &fakeProvider{}
+		results := New(provider).Execute(targets, Options{Action: ActionKill, StopUnits: true})
+
+		want := []string{
+			"stop nginx.service",
+			"--user stop syncthing.service",
+			"--machine=" + other + "@ --user stop syncthing.service",
+			"stop broken.service",
+		}
+		if calls := systemctlCalls(t, logPath); !slices.Equal(calls, want) {
+			t.Errorf("systemctl calls = %q, want %q", calls, want)
+		}
+		if !slices.Equal(provider.killed, []int32{30}) {
+			t.Errorf("killed %v, want [30]", provider.killed)
+		}
+		for _, r := range results {
+			wantSuccess := r.PID != 40
+			if r.Success != wantSuccess {
+				t.Errorf("PID %d success = %v (%v), want %v", r.PID, r.Success, r.Error, wantSuccess)
+			}
+		}
+		if results[0].Unit != "nginx.service" || results[4].Unit != "" {
+			t.Errorf("unexpected units in results: %+v", results)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		logPath := stubSystemctl(t)
+		provider := &fakeProvider{}
+		results := New(provider).Execute(targets, Options{Action: ActionKill, StopUnits: true, DryRun: true})
+
+		if calls := systemctlCalls(t, logPath); len(calls) > 0 {
+			t.Errorf("systemctl called during dry run: %q", calls)
+		}
+		if len(provider.killed) > 0 {
+			t.Errorf("killed %v during dry run", provider.killed)
+		}
+		if got := FormatResult(results[0]); got != "[dry-run] would stop unit nginx.service (nginx, PID 10)" {
+			t.Errorf("FormatResult = %q", got)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package process
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+var containerPatterns = []struct {
+	runtime string
+	re      *regexp.Regexp
+}{
+	{"docker", regexp.MustCompile(`(?:^|/)docker[-/]([0-9a-f]{64})(?:\.scope)?(?:/|$)`)},
+	{"podman", regexp.MustCompile(`(?:^|/)libpod-([0-9a-f]{64})(?:\.scope)?(?:/|$)`)},
+	{"containerd", regexp.MustCompile(`(?:^|/)cri-containerd-([0-9a-f]{64})\.scope(?:/|$)`)},
+	{"cri-o", regexp.MustCompile(`(?:^|/)crio-([0-9a-f]{64})\.scope(?:/|$)`)},
+	{"kubernetes", regexp.MustCompile(`(?:^|/)kubepods[^/]*(?:/[^/]+)*?/([0-9a-f]{64})(?:/|$)`)},
+	{"lxc", regexp.MustCompile(`(?:^|/)lxc(?:\.payload\.|/)([^/]+)`)},
+}
+
+const userManagerPrefix = "user@"
+
+func readCgroupPaths(pid int32) (paths []string, systemdPath string) {
+	data, err := os.ReadFile(procPath(pid, "cgroup"))
+	if err != nil {
+		return nil, ""
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 || parts[2] == "/" {
+			continue
+		}
+		paths = append(paths, parts[2])
+		if parts[1] == "name=systemd" || (parts[0] == "0" && systemdPath == "") {
+			systemdPath = parts[2]
+		}
+	}
+	return paths, systemdPath
+}
+
+func unitFromCgroup(path string) (unit, owner string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		seg := segments[i]
+		if !strings.HasSuffix(seg, ".service") && !strings.HasSuffix(seg, ".scope") {
+			continue
+		}
+		if strings.HasPrefix(seg, userManagerPrefix) && i < len(segments)-1 {
+			return "", ""
+		}
+		for _, parent := range segments[:i] {
+			if uid, ok := strings.CutPrefix(parent, userManagerPrefix); ok {
+				return seg, strings.TrimSuffix(uid, ".service")
+			}
+		}
+		return seg, ""
+	}
+	return "", ""
+}
+
+func containerFromCgroup(paths []string) (id, runtime string) {
+	for _, path := range paths {
+		for _, p := range containerPatterns {
+			if m := p.re.FindStringSubmatch(path); m != nil {
+				return m[1], p.runtime
+			}
+		}
+	}
+	return "", ""
+}
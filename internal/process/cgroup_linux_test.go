@@ -0,0 +1,59 @@
+package process
+
+import "testing"
+
+func TestContainerFromCgroup(t *testing.T) {
+	const id = "3f4e2b1c0d9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c"
+	tests := []struct {
+		path    string
+		id      string
+		runtime string
+	}{
+		{"/system.slice/docker-" + id + ".scope", id, "docker"},
+		{"/docker/" + id, id, "docker"},
+		{"/machine.slice/libpod-" + id + ".scope/container", id, "podman"},
+		{"/system.slice/cri-containerd-" + id + ".scope", id, "containerd"},
+		{"/kubepods.slice/kubepods-burstable.slice/crio-" + id + ".scope", id, "cri-o"},
+		{"/kubepods/burstable/pod1234/" + id, id, "kubernetes"},
+		{"/lxc.payload.web/init.scope", "web", "lxc"},
+		{"/lxc/web", "web", "lxc"},
+		{"/lxc.monitor.web", "", ""},
+		{"/user.slice/user-1000.slice/session-2.scope", "", ""},
+		{"/system.slice/nginx.service", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			id, runtime := containerFromCgroup([]string{tt.path})
+			if id != tt.id || runtime != tt.runtime {
+				t.Fatalf("containerFromCgroup(%q) = %q, %q, want %q, %q", tt.path, id, runtime, tt.id, tt.runtime)
+			}
+		})
+	}
+}
+
+func TestUnitFromCgroup(t *testing.T) {
+	tests := []struct {
+		path  string
+		unit  string
+		owner string
+	}{
+		{"/system.slice/nginx.service", "nginx.service", ""},
+		{"/system.slice/system-getty.slice/getty@tty1.service", "getty@tty1.service", ""},
+		{"/user.slice/user-1000.slice/user@1000.service/app.slice/syncthing.service", "syncthing.service", "1000"},
+		{"/user.slice/user-0.slice/user@0.service/app.slice/syncthing.service", "syncthing.service", "0"},
+		{"/user.slice/user-1000.slice/user@1000.service/init.scope", "init.scope", "1000"},
+		{"/user.slice/user-1000.slice/user@1000.service", "user@1000.service", ""},
+		{"/user.slice/user-1000.slice/session-2.scope", "session-2.scope", ""},
+		{"/system.slice/docker-3f4e2b1c0d9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c.scope", "docker-3f4e2b1c0d9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c.scope", ""},
+		{"/docker/3f4e2b1c0d9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c", "", ""},
+		{"/", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			unit, owner := unitFromCgroup(tt.path)
+			if unit != tt.unit || owner != tt.owner {
+				t.Fatalf("unitFromCgroup(%q) = %q, %q, want %q, %q", tt.path, unit, owner, tt.unit, tt.owner)
+			}
+		})
+	}
+}
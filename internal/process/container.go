@@ -0,0 +1,29 @@
+package process
+
+import (
+	"os/exec"
+	"strings"
+)
+
+func ContainerNames(runtime string) map[string]string {
+	if runtime != "docker" && runtime != "podman" {
+		return nil
+	}
+	if _, err := exec.LookPath(runtime); err != nil {
+		return nil
+	}
+
+	out, err := exec.Command(runtime, "ps", "--no-trunc", "--format", "{{.ID}} {{.Names}}").Output()
+	if err != nil {
+		return nil
+	}
+
+	names := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		id, name, ok := strings.Cut(line, " ")
+		if ok {
+			names[id] = strings.TrimPrefix(name, "/")
+		}
+	}
+	return names
+}
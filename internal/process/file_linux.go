@@ -0,0 +1,144 @@
+package process
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	AccessFD   = "fd"
+	AccessMmap = "mmap"
+	AccessCwd  = "cwd"
+	AccessRoot = "root"
+	AccessExe  = "exe"
+)
+
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+func (p *linuxProvider) findByFile(path string, mount bool) ([]Info, error) {
+	target, err := statID(path)
+	if err != nil {
+		return nil, err
+	}
+
+	match := func(id fileID) bool {
+		if mount {
+			return id.dev == target.dev
+		}
+		return id == target
+	}
+
+	pids, err := listPIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	accessByPID := make(map[int32][]string)
+	var holders []int32
+	for _, pid := range pids {
+		if st, err := readStat(pid); err == nil && st.kernelThread {
+			continue
+		}
+		access := fileAccess(pid, match)
+		if len(access) == 0 {
+			continue
+		}
+		accessByPID[pid] = access
+		holders = append(holders, pid)
+	}
+
+	result := p.infosForPIDs(holders)
+	for i := range result {
+		result[i].Access = accessByPID[result[i].PID]
+	}
+	return result, nil
+}
+
+func fileAccess(pid int32, match func(fileID) bool) []string {
+	var access []string
+	for _, link := range []struct {
+		name string
+		kind string
+	}{
+		{"root", AccessRoot},
+		{"cwd", AccessCwd},
+		{"exe", AccessExe},
+	} {
+		if id, err := statID(procPath(pid, link.name)); err == nil && match(id) {
+			access = append(access, link.kind)
+		}
+	}
+
+	if holdsFD(pid, match) {
+		access = append(access, AccessFD)
+	}
+	if mapsFile(pid, match) {
+		access = append(access, AccessMmap)
+	}
+	return access
+}
+
+func holdsFD(pid int32, match func(fileID) bool) bool {
+	dir := procPath(pid, "fd")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if id, err := statID(filepath.Join(dir, e.Name())); err == nil && match(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapsFile(pid int32, match func(fileID) bool) bool {
+	f, err := os.Open(procPath(pid, "maps"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || fields[4] == "0" {
+			continue
+		}
+		major, minor, ok := strings.Cut(fields[3], ":")
+		if !ok {
+			continue
+		}
+		maj, err1 := strconv.ParseUint(major, 16, 32)
+		minr, err2 := strconv.ParseUint(minor, 16, 32)
+		ino, err3 := strconv.ParseUint(fields[4], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		if match(fileID{dev: unix.Mkdev(uint32(maj), uint32(minr)), ino: ino}) {
+			return true
+		}
+	}
+	return false
+}
+
+func statID(path string) (fileID, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileID{}, err
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileID{}, ErrUnsupported
+	}
+	return fileID{dev: st.Dev, ino: st.Ino}, nil
+}
@@ -0,0 +1,206 @@
+package process
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+}
+
+type inetSocket struct {
+	Socket
+	inode uint64
+}
+
+func (p *linuxProvider) inetSockets() ([]inetSocket, error) {
+	if !p.socketsRead {
+		p.sockets, p.socketsErr = readInetSockets()
+		p.socketsRead = true
+	}
+	return p.sockets, p.socketsErr
+}
+
+func (p *linuxProvider) bindingsFor(pids []int32) map[int32][]Binding {
+	if p.allBindings != nil {
+		return p.allBindings
+	}
+	sockets, err := p.inetSockets()
+	if err != nil {
+		return nil
+	}
+	return bindingsForPIDs(pids, sockets)
+}
+
+func (p *linuxProvider) bindingsForAll(pids []int32) map[int32][]Binding {
+	if p.allBindings == nil {
+		sockets, _ := p.inetSockets()
+		p.allBindings = bindingsForPIDs(pids, sockets)
+		if p.allBindings == nil {
+			p.allBindings = map[int32][]Binding{}
+		}
+	}
+	return p.allBindings
+}
+
+func (p *linuxProvider) findBySocket(match func(Socket) bool) ([]Info, error) {
+	sockets, err := p.inetSockets()
+	if err != nil {
+		return nil, err
+	}
+	inodes := make(map[uint64]bool)
+	for _, s := range sockets {
+		if s.inode != 0 && match(s.Socket) {
+			inodes[s.inode] = true
+		}
+	}
+	if len(inodes) == 0 {
+		return nil, nil
+	}
+	pids, err := pidsHoldingSockets(inodes)
+	if err != nil {
+		return nil, err
+	}
+	return p.infosForPIDs(pids), nil
+}
+
+func bindingsForPIDs(pids []int32, sockets []inetSocket) map[int32][]Binding {
+	listening := make(map[uint64]Socket)
+	inodes := make(map[uint64]bool)
+	for _, s := range sockets {
+		if s.inode != 0 && s.Listening() {
+			listening[s.inode] = s.Socket
+			inodes[s.inode] = true
+		}
+	}
+	if len(inodes) == 0 {
+		return nil
+	}
+
+	var owned []Socket
+	for _, pid := range pids {
+		for _, target := range readFDLinks(pid) {
+			if inode, ok := socketInode(target); ok && inodes[inode] {
+				s := listening[inode]
+				s.PID = pid
+				owned = append(owned, s)
+			}
+		}
+	}
+	return bindingMapFromSockets(owned)
+}
+
+func readInetSockets() ([]inetSocket, error) {
+	var sockets []inetSocket
+	for _, table := range []struct {
+		name  string
+		proto string
+	}{
+		{"tcp", ProtoTCP},
+		{"tcp6", ProtoTCP},
+		{"udp", ProtoUDP},
+		{"udp6", ProtoUDP},
+	} {
+		parsed, err := readInetTable(filepath.Join(procRoot, "net", table.name), table.proto)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		sockets = append(sockets, parsed...)
+	}
+	return sockets, nil
+}
+
+func readInetTable(path, proto string) ([]inetSocket, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sockets []inetSocket
+	scanner := bufio.NewScanner(f)
+	scanner.Scan()
+	for scanner.Scan() {
+		s, err := parseInetLine(scanner.Text(), proto)
+		if err != nil {
+			continue
+		}
+		sockets = append(sockets, s)
+	}
+	return sockets, scanner.Err()
+}
+
+func parseInetLine(line, proto string) (inetSocket, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 10 {
+		return inetSocket{}, fmt.Errorf("malformed socket line")
+	}
+	ip, port, err := parseInetAddr(fields[1])
+	if err != nil {
+		return inetSocket{}, err
+	}
+	remoteIP, remotePort, err := parseInetAddr(fields[2])
+	if err != nil {
+		return inetSocket{}, err
+	}
+	inode, err := strconv.ParseUint(fields[9], 10, 64)
+	if err != nil {
+		return inetSocket{}, err
+	}
+
+	status := "NONE"
+	if proto == ProtoTCP {
+		status = tcpStates[fields[3]]
+	}
+	return inetSocket{
+		Socket: Socket{
+			Proto:      proto,
+			IP:         ip,
+			Port:       port,
+			RemoteIP:   remoteIP,
+			RemotePort: remotePort,
+			Status:     status,
+		},
+		inode: inode,
+	}, nil
+}
+
+func parseInetAddr(addr string) (string, uint32, error) {
+	host, portHex, ok := strings.Cut(addr, ":")
+	if !ok || (len(host) != 8 && len(host) != 32) {
+		return "", 0, fmt.Errorf("malformed socket address %q", addr)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed socket address %q", addr)
+	}
+	ip := make(net.IP, 0, len(host)/2)
+	for i := 0; i < len(host); i += 8 {
+		word, err := strconv.ParseUint(host[i:i+8], 16, 32)
+		if err != nil {
+			return "", 0, fmt.Errorf("malformed socket address %q", addr)
+		}
+		ip = binary.NativeEndian.AppendUint32(ip, uint32(word))
+	}
+	return ip.String(), uint32(port), nil
+}
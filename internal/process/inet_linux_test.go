@@ -0,0 +1,262 @@
+package process
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"syscall"
+	"testing"
+
+	gopsNet "github.com/shirou/gopsutil/v4/net"
+	gopsProcess "github.com/shirou/gopsutil/v4/process"
+)
+
+func TestReadInetSockets(t *testing.T) {
+	useProcRoot(t, "testdata/proc")
+
+	sockets, err := readInetSockets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sockets) != 480 {
+		t.Fatalf("read %d sockets, want 480", len(sockets))
+	}
+
+	tests := []struct {
+		inode uint64
+		want  Socket
+	}{
+		{700001, Socket{Proto: ProtoTCP, IP: "127.0.0.1", Port: 3000, RemoteIP: "0.0.0.0", Status: "LISTEN"}},
+		{700003, Socket{Proto: ProtoTCP, IP: "192.168.1.10", Port: 22, RemoteIP: "192.168.1.20", RemotePort: 51234, Status: "ESTABLISHED"}},
+		{700301, Socket{Proto: ProtoTCP, IP: "::", Port: 8080, RemoteIP: "::", Status: "LISTEN"}},
+		{700302, Socket{Proto: ProtoTCP, IP: "::1", Port: 5432, RemoteIP: "::", Status: "LISTEN"}},
+		{700401, Socket{Proto: ProtoUDP, IP: "0.0.0.0", Port: 53, RemoteIP: "0.0.0.0", Status: "NONE"}},
+		{700461, Socket{Proto: ProtoUDP, IP: "::", Port: 5353, RemoteIP: "::", Status: "NONE"}},
+	}
+	for _, tt := range tests {
+		i := slices.IndexFunc(sockets, func(s inetSocket) bool { return s.inode == tt.inode })
+		if i < 0 {
+			t.Errorf("inode %d not found", tt.inode)
+			continue
+		}
+		if sockets[i].Socket != tt.want {
+			t.Errorf("inode %d = %+v, want %+v", tt.inode, sockets[i].Socket, tt.want)
+		}
+	}
+}
+
+func TestProviderCachesSocketTables(t *testing.T) {
+	useProcRoot(t, "testdata/proc")
+	p := &linuxProvider{}
+	if _, err := p.inetSockets(); err != nil {
+		t.Fatal(err)
+	}
+
+	useProcRoot(t, t.TempDir())
+	sockets, err := p.inetSockets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sockets) != 480 {
+		t.Fatalf("second read returned %d sockets, want the 480 cached ones", len(sockets))
+	}
+	if fresh, _ := (&linuxProvider{}).inetSockets(); len(fresh) != 0 {
+		t.Fatalf("new provider read %d sockets from an empty root, want 0", len(fresh))
+	}
+}
+
+func TestParseInetLineErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"0: 0100007F:0BB8 00000000:0000 0A",
+		"0: 0100007F 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 1",
+		"0: 0100007F:XYZ 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 1",
+		"0: 0100007G:0BB8 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 1",
+		"0: 0100007F:0BB8 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 inode",
+	} {
+		if _, err := parseInetLine(line, ProtoTCP); err == nil {
+			t.Errorf("parseInetLine(%q) succeeded, want error", line)
+		}
+	}
+}
+
+func BenchmarkFindByPort(b *testing.B) {
+	port := fixtureWithListener(b)
+	match := func(s Socket) bool { return s.Port == port && s.Listening() }
+
+	b.Run("gopsutil", func(b *testing.B) {
+		for b.Loop() {
+			if infos := gopsutilFindBySocket(match); len(infos) != 1 {
+				b.Fatalf("found %d processes on port %d, want 1", len(infos), port)
+			}
+		}
+	})
+	b.Run("procfs", func(b *testing.B) {
+		for b.Loop() {
+			if infos, err := New().FindByPort(port); err != nil || len(infos) != 1 {
+				b.Fatalf("found %d processes on port %d (%v), want 1", len(infos), port, err)
+			}
+		}
+	})
+}
+
+func BenchmarkList(b *testing.B) {
+	fixtureWithListener(b)
+
+	b.Run("gopsutil", func(b *testing.B) {
+		for b.Loop() {
+			if len(gopsutilList()) == 0 {
+				b.Fatal("listed no processes")
+			}
+		}
+	})
+	b.Run("procfs", func(b *testing.B) {
+		for b.Loop() {
+			if infos, err := New().List(); err != nil || len(infos) == 0 {
+				b.Fatalf("listed no processes (%v)", err)
+			}
+		}
+	})
+}
+
+func useProcRoot(tb testing.TB, root string) {
+	tb.Helper()
+	old := procRoot
+	procRoot = root
+	tb.Cleanup(func() { procRoot = old })
+	tb.Setenv("HOST_PROC", root)
+}
+
+func fixtureWithListener(b *testing.B) uint32 {
+	b.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { l.Close() })
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	inode := listenerInode(b, l.(*net.TCPListener))
+
+	root := b.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "net"), 0o755); err != nil {
+		b.Fatal(err)
+	}
+	for _, name := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "proc", "net", name))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if name == "tcp" {
+			data = fmt.Appendf(data, "%4d: 0100007F:%04X 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 %d 1 0000000000000000 100 0 0 10 0\n", 300, port, inode)
+		}
+		if err := os.WriteFile(filepath.Join(root, "net", name), data, 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	pids, err := listPIDs()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, pid := range pids {
+		if err := os.Symlink(procPath(pid), filepath.Join(root, strconv.Itoa(int(pid)))); err != nil {
+			b.Fatal(err)
+		}
+	}
+	useProcRoot(b, root)
+	return port
+}
+
+func listenerInode(b *testing.B, l *net.TCPListener) uint64 {
+	b.Helper()
+	f, err := l.File()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer f.Close()
+	link, err := os.Readlink(filepath.Join(procRoot, "self", "fd", strconv.Itoa(int(f.Fd()))))
+	if err != nil {
+		b.Fatal(err)
+	}
+	inode, ok := socketInode(link)
+	if !ok {
+		b.Fatalf("fd link %q is not a socket", link)
+	}
+	return inode
+}
+
+func gopsutilSockets() []Socket {
+	conns, err := gopsNet.Connections("inet")
+	if err != nil {
+		return nil
+	}
+	sockets := make([]Socket, 0, len(conns))
+	for _, conn := range conns {
+		if conn.Pid == 0 {
+			continue
+		}
+		proto := ProtoTCP
+		if conn.Type == syscall.SOCK_DGRAM {
+			proto = ProtoUDP
+		}
+		sockets = append(sockets, Socket{
+			PID:        conn.Pid,
+			Proto:      proto,
+			IP:         conn.Laddr.IP,
+			Port:       conn.Laddr.Port,
+			RemoteIP:   conn.Raddr.IP,
+			RemotePort: conn.Raddr.Port,
+			Status:     conn.Status,
+		})
+	}
+	return sockets
+}
+
+func gopsutilInfo(proc *gopsProcess.Process, bindingMap map[int32][]Binding) Info {
+	info := newInfo(proc)
+	info.Bindings = bindingMap[proc.Pid]
+	if ch, err := proc.Children(); err == nil {
+		for _, c := range ch {
+			info.Children = append(info.Children, c.Pid)
+		}
+	}
+	return info
+}
+
+func gopsutilFindBySocket(match func(Socket) bool) []Info {
+	sockets := gopsutilSockets()
+	bindingMap := bindingMapFromSockets(sockets)
+	var pids []int32
+	for _, s := range sockets {
+		if !slices.Contains(pids, s.PID) && match(s) {
+			pids = append(pids, s.PID)
+		}
+	}
+	slices.Sort(pids)
+
+	var result []Info
+	for _, pid := range pids {
+		proc, err := gopsProcess.NewProcess(pid)
+		if err != nil {
+			continue
+		}
+		result = append(result, gopsutilInfo(proc, bindingMap))
+	}
+	return result
+}
+
+func gopsutilList() []Info {
+	procs, err := gopsProcess.Processes()
+	if err != nil {
+		return nil
+	}
+	bindingMap := bindingMapFromSockets(gopsutilSockets())
+	result := make([]Info, 0, len(procs))
+	for _, proc := range procs {
+		result = append(result, gopsutilInfo(proc, bindingMap))
+	}
+	return result
+}
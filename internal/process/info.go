@@ -1,60 +1,156 @@
 package process
 
 import (
+	"cmp"
+	"os"
+	"slices"
 	"time"
 
-	gopsNet "github.com/shirou/gopsutil/v4/net"
+	gopsMem "github.com/shirou/gopsutil/v4/mem"
 	gopsProcess "github.com/shirou/gopsutil/v4/process"
 )
 
-func procToInfo(proc *gopsProcess.Process, portMap map[int32]uint32) Info {
+func newInfo(proc *gopsProcess.Process) Info {
 	pid := proc.Pid
 	name, _ := proc.Name()
 	cmdline, _ := proc.Cmdline()
+	exe, _ := proc.Exe()
+	cwd, _ := proc.Cwd()
 	user, _ := proc.Username()
 	ppid, _ := proc.Ppid()
 	cpu, _ := proc.CPUPercent()
 	memInfo, _ := proc.MemoryInfo()
 	createMs, _ := proc.CreateTime()
+	status, _ := proc.Status()
 
 	var memRSS uint64
 	if memInfo != nil {
 		memRSS = memInfo.RSS
 	}
 
-	var children []int32
-	if ch, err := proc.Children(); err == nil {
-		for _, c := range ch {
-			children = append(children, c.Pid)
-		}
+	var created time.Time
+	if createMs > 0 {
+		created = time.UnixMilli(createMs)
 	}
 
-	return Info{
+	info := Info{
 		PID:        pid,
 		PPID:       ppid,
 		Name:       name,
 		Cmdline:    cmdline,
+		Exe:        exe,
+		Cwd:        cwd,
 		User:       user,
-		Port:       portMap[pid],
 		CPUPercent: cpu,
 		MemRSS:     memRSS,
-		CreateTime: time.UnixMilli(createMs),
-		Children:   children,
+		CreateTime: created,
+		State:      stateFromStatus(status),
 	}
+	fillPlatformInfo(&info)
+	return info
 }
 
-func buildPortMap() map[int32]uint32 {
-	portMap := make(map[int32]uint32)
-	conns, err := gopsNet.Connections("all")
+func stateFromStatus(status []string) string {
+	if len(status) == 0 {
+		return ""
+	}
+	switch status[0] {
+	case gopsProcess.Running:
+		return StateRunning
+	case gopsProcess.Sleep, gopsProcess.Wait:
+		return StateSleeping
+	case gopsProcess.Blocked:
+		return StateDiskWait
+	case gopsProcess.Zombie:
+		return StateZombie
+	case gopsProcess.Stop:
+		return StateStopped
+	case gopsProcess.Idle:
+		return StateIdle
+	default:
+		return ""
+	}
+}
+
+func sampleCPU(procs []Info, interval time.Duration) []Info {
+	type sample struct {
+		proc *gopsProcess.Process
+		busy float64
+	}
+	samples := make(map[int32]sample, len(procs))
+	for _, info := range procs {
+		proc, err := gopsProcess.NewProcess(info.PID)
+		if err != nil {
+			continue
+		}
+		times, err := proc.Times()
+		if err != nil {
+			continue
+		}
+		samples[info.PID] = sample{proc: proc, busy: times.User + times.System}
+	}
+
+	start := time.Now()
+	time.Sleep(interval)
+	elapsed := time.Since(start).Seconds()
+
+	result := make([]Info, 0, len(procs))
+	for _, info := range procs {
+		s, ok := samples[info.PID]
+		if !ok {
+			result = append(result, info)
+			continue
+		}
+		times, err := s.proc.Times()
+		if err != nil {
+			continue
+		}
+		info.CPUPercent = (times.User + times.System - s.busy) / elapsed * 100
+		result = append(result, info)
+	}
+	return result
+}
+
+func TotalMemory() (uint64, error) {
+	vm, err := gopsMem.VirtualMemory()
 	if err != nil {
-		return portMap
+		return 0, err
+	}
+	return vm.Total, nil
+}
+
+func bindingMapFromSockets(sockets []Socket) map[int32][]Binding {
+	bindingMap := make(map[int32][]Binding)
+	for _, s := range sockets {
+		if !s.Listening() {
+			continue
+		}
+		b := Binding{Proto: s.Proto, Addr: s.IP, Port: s.Port}
+		if !slices.Contains(bindingMap[s.PID], b) {
+			bindingMap[s.PID] = append(bindingMap[s.PID], b)
+		}
+	}
+	for _, bindings := range bindingMap {
+		slices.SortFunc(bindings, func(a, b Binding) int {
+			return cmp.Or(cmp.Compare(a.Port, b.Port), cmp.Compare(a.Proto, b.Proto), cmp.Compare(a.Addr, b.Addr))
+		})
 	}
-	for _, conn := range conns {
-		if conn.Status == "LISTEN" && conn.Pid != 0 {
-			if _, exists := portMap[conn.Pid]; !exists {
-				portMap[conn.Pid] = conn.Laddr.Port
-			}
+	return bindingMap
+}
+
+func SelfAndAncestors() map[int32]bool {
+	pids := map[int32]bool{int32(os.Getpid()): true}
+	for pid := int32(os.Getppid()); pid > 1 && !pids[pid]; {
+		pids[pid] = true
+		proc, err := gopsProcess.NewProcess(pid)
+		if err != nil {
+			break
+		}
+		ppid, err := proc.Ppid()
+		if err != nil {
+			break
 		}
+		pid = ppid
 	}
-	return portMap
+	return pids
 }
@@ -0,0 +1,82 @@
+//go:build !linux
+
+package process
+
+import (
+	"slices"
+
+	gopsNet "github.com/shirou/gopsutil/v4/net"
+	gopsProcess "github.com/shirou/gopsutil/v4/process"
+)
+
+const sockDgram = 2
+
+func procToInfo(proc *gopsProcess.Process, bindingMap map[int32][]Binding) Info {
+	info := newInfo(proc)
+	info.Bindings = bindingMap[proc.Pid]
+	if ch, err := proc.Children(); err == nil {
+		for _, c := range ch {
+			info.Children = append(info.Children, c.Pid)
+		}
+	}
+	return info
+}
+
+func listSockets() []Socket {
+	conns, err := gopsNet.Connections("inet")
+	if err != nil {
+		return nil
+	}
+	sockets := make([]Socket, 0, len(conns))
+	for _, conn := range conns {
+		if conn.Pid == 0 {
+			continue
+		}
+		proto := ProtoTCP
+		if conn.Type == sockDgram {
+			proto = ProtoUDP
+		}
+		sockets = append(sockets, Socket{
+			PID:        conn.Pid,
+			Proto:      proto,
+			IP:         conn.Laddr.IP,
+			Port:       conn.Laddr.Port,
+			RemoteIP:   conn.Raddr.IP,
+			RemotePort: conn.Raddr.Port,
+			Status:     conn.Status,
+		})
+	}
+	return sockets
+}
+
+func buildBindingMap() map[int32][]Binding {
+	return bindingMapFromSockets(listSockets())
+}
+
+func findBySocket(match func(Socket) bool) ([]Info, error) {
+	sockets := listSockets()
+	bindingMap := bindingMapFromSockets(sockets)
+
+	matched := make(map[int32]bool)
+	for _, s := range sockets {
+		if !matched[s.PID] && match(s) {
+			matched[s.PID] = true
+		}
+	}
+
+	pids := make([]int32, 0, len(matched))
+	for pid := range matched {
+		pids = append(pids, pid)
+	}
+	slices.Sort(pids)
+
+	result := make([]Info, 0, len(pids))
+	for _, pid := range pids {
+		proc, err := gopsProcess.NewProcess(pid)
+		if err != nil {
+			continue
+		}
+		result = append(result, procToInfo(proc, bindingMap))
+	}
+	return result, nil
+}
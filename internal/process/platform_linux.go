@@ -0,0 +1,17 @@
+package process
+
+func fillPlatformInfo(info *Info) {
+	paths, systemdPath := readCgroupPaths(info.PID)
+	info.ContainerID, info.ContainerRuntime = containerFromCgroup(paths)
+	info.Unit, info.UnitOwner = unitFromCgroup(systemdPath)
+
+	if st, err := readStat(info.PID); err == nil {
+		info.PGID = st.pgid
+		info.SID = st.sid
+		info.TTY = ttyName(st.ttyNr)
+		info.KernelThread = st.kernelThread
+	}
+	if info.State != StateRunning && info.State != StateZombie {
+		info.WChan = readWChan(info.PID)
+	}
+}
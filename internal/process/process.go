@@ -1,16 +1,104 @@
 package process
 
-import "time"
+import (
+	"net"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	StateRunning  = "R"
+	StateSleeping = "S"
+	StateDiskWait = "D"
+	StateZombie   = "Z"
+	StateStopped  = "T"
+	StateIdle     = "I"
+)
 
 type Info struct {
+	PID              int32
+	PPID             int32
+	Name             string
+	Cmdline          string
+	Exe              string
+	Cwd              string
+	User             string
+	Bindings         []Binding
+	CPUPercent       float64
+	MemRSS           uint64
+	CreateTime       time.Time
+	Children         []int32
+	Access           []string
+	ContainerID      string
+	ContainerRuntime string
+	Unit             string
+	UnitOwner        string
+	TTY              string
+	SID              int32
+	PGID             int32
+	State            string
+	WChan            string
+	KernelThread     bool
+}
+
+const (
+	ProtoTCP = "tcp"
+	ProtoUDP = "udp"
+)
+
+type Binding struct {
+	Proto string
+	Addr  string
+	Port  uint32
+}
+
+func (b Binding) String() string {
+	s := strconv.FormatUint(uint64(b.Port), 10)
+	if !isWildcardAddr(b.Addr) {
+		s = net.JoinHostPort(b.Addr, s)
+	}
+	if b.Proto == ProtoUDP {
+		s = ProtoUDP + ":" + s
+	}
+	return s
+}
+
+func isWildcardAddr(addr string) bool {
+	switch addr {
+	case "", "*", "0.0.0.0", "::":
+		return true
+	}
+	return false
+}
+
+func (i Info) Ports() []uint32 {
+	ports := make([]uint32, 0, len(i.Bindings))
+	for _, b := range i.Bindings {
+		ports = append(ports, b.Port)
+	}
+	slices.Sort(ports)
+	return slices.Compact(ports)
+}
+
+var SocketStates = []string{
+	"LISTEN", "ESTABLISHED", "SYN_SENT", "SYN_RECV", "FIN_WAIT1", "FIN_WAIT2",
+	"TIME_WAIT", "CLOSE", "CLOSE_WAIT", "LAST_ACK", "CLOSING", "NONE",
+}
+
+type Socket struct {
 	PID        int32
-	PPID       int32
-	Name       string
-	Cmdline    string
-	User       string
+	Proto      string
+	IP         string
 	Port       uint32
-	CPUPercent float64
-	MemRSS     uint64
-	CreateTime time.Time
-	Children   []int32
+	RemoteIP   string
+	RemotePort uint32
+	Status     string
+}
+
+func (s Socket) Listening() bool {
+	if s.Proto == ProtoUDP {
+		return s.RemotePort == 0
+	}
+	return s.Status == "LISTEN"
 }
@@ -0,0 +1,149 @@
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	gopsProcess "github.com/shirou/gopsutil/v4/process"
+)
+
+var procRoot = "/proc"
+
+func listPIDs() ([]int32, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+	pids := make([]int32, 0, len(entries))
+	for _, e := range entries {
+		pid, err := strconv.ParseInt(e.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		pids = append(pids, int32(pid))
+	}
+	slices.Sort(pids)
+	return pids, nil
+}
+
+func procPath(pid int32, parts ...string) string {
+	return filepath.Join(append([]string{procRoot, strconv.Itoa(int(pid))}, parts...)...)
+}
+
+func readFDLinks(pid int32) map[string]string {
+	dir := procPath(pid, "fd")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	links := make(map[string]string, len(entries))
+	for _, e := range entries {
+		target, err := os.Readlink(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		links[e.Name()] = target
+	}
+	return links
+}
+
+func readEnviron(pid int32) ([]string, error) {
+	data, err := os.ReadFile(procPath(pid, "environ"))
+	if err != nil {
+		return nil, err
+	}
+	var env []string
+	for _, kv := range strings.Split(string(data), "\x00") {
+		if kv != "" {
+			env = append(env, kv)
+		}
+	}
+	return env, nil
+}
+
+func pidsHoldingSockets(inodes map[uint64]bool) ([]int32, error) {
+	pids, err := listPIDs()
+	if err != nil {
+		return nil, err
+	}
+	var result []int32
+	for _, pid := range pids {
+		for _, target := range readFDLinks(pid) {
+			if inode, ok := socketInode(target); ok && inodes[inode] {
+				result = append(result, pid)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func socketInode(link string) (uint64, bool) {
+	const prefix, suffix = "socket:[", "]"
+	if len(link) <= len(prefix)+len(suffix) || link[:len(prefix)] != prefix || link[len(link)-1:] != suffix {
+		return 0, false
+	}
+	inode, err := strconv.ParseUint(link[len(prefix):len(link)-1], 10, 64)
+	return inode, err == nil
+}
+
+func (p *linuxProvider) infosForPIDs(pids []int32) []Info {
+	if len(pids) == 0 {
+		return nil
+	}
+	return buildInfos(pids, p.bindingsFor(pids), p.childrenOf)
+}
+
+func buildInfos(pids []int32, bindingMap map[int32][]Binding, children func(int32) []int32) []Info {
+	result := make([]Info, 0, len(pids))
+	for _, pid := range pids {
+		proc, err := gopsProcess.NewProcess(pid)
+		if err != nil {
+			continue
+		}
+		info := newInfo(proc)
+		info.Bindings = bindingMap[pid]
+		if children != nil {
+			info.Children = children(pid)
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+func (p *linuxProvider) childrenOf(pid int32) []int32 {
+	if children, ok := readChildren(pid); ok {
+		return children
+	}
+	if p.children == nil {
+		p.children = childPIDs()
+		if p.children == nil {
+			p.children = map[int32][]int32{}
+		}
+	}
+	return p.children[pid]
+}
+
+func readChildren(pid int32) ([]int32, bool) {
+	tasks, err := os.ReadDir(procPath(pid, "task"))
+	if err != nil {
+		return nil, false
+	}
+	var children []int32
+	for _, task := range tasks {
+		data, err := os.ReadFile(procPath(pid, "task", task.Name(), "children"))
+		if err != nil {
+			return nil, false
+		}
+		for _, field := range strings.Fields(string(data)) {
+			if child, err := strconv.ParseInt(field, 10, 32); err == nil {
+				children = append(children, int32(child))
+			}
+		}
+	}
+	slices.Sort(children)
+	return slices.Compact(children), true
+}
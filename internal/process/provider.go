@@ -1,6 +1,20 @@
 package process
 
-import "syscall"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	gopsProcess "github.com/shirou/gopsutil/v4/process"
+)
+
+var (
+	ErrUnsupported = errors.New("not supported on this platform")
+	ErrNotFound    = gopsProcess.ErrorProcessNotRunning
+)
 
 type Signal syscall.Signal
 
@@ -11,13 +25,39 @@ const (
 	SignalHup  Signal = Signal(syscall.SIGHUP)
 )
 
+func ParseSignal(name string) (Signal, error) {
+	key := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "SIG")
+	if sig, ok := signals[key]; ok {
+		return sig, nil
+	}
+	if n, err := strconv.Atoi(key); err == nil && n > 0 && numericSignals {
+		return Signal(n), nil
+	}
+	return 0, fmt.Errorf("unknown signal %q%s", name, signalHint)
+}
+
+func (s Signal) Name() string {
+	for name, sig := range signals {
+		if sig == s {
+			return "SIG" + name
+		}
+	}
+	return strconv.Itoa(int(s))
+}
+
 type Provider interface {
 	List() ([]Info, error)
 	FindByPID(pid int32) (*Info, error)
 	FindByPort(port uint32) ([]Info, error)
+	FindBySocket(match func(Socket) bool) ([]Info, error)
+	FindByUnixSocket(path string) ([]Info, error)
+	FindByFile(path string, mount bool) ([]Info, error)
+	Environ(pid int32) ([]string, error)
+	SampleCPU(procs []Info, interval time.Duration) []Info
 	Children(pid int32) ([]Info, error)
 	Kill(pid int32) error
 	Terminate(pid int32) error
 	Signal(pid int32, sig Signal) error
+	Resume(pid int32) error
 	IsRunning(pid int32) bool
 }
@@ -2,6 +2,7 @@ package process
 
 import (
 	"syscall"
+	"time"
 
 	gopsProcess "github.com/shirou/gopsutil/v4/process"
 )
@@ -17,10 +18,10 @@ func (p *darwinProvider) List() ([]Info, error) {
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
+	bindingMap := buildBindingMap()
 	result := make([]Info, 0, len(procs))
 	for _, proc := range procs {
-		info := procToInfo(proc, portMap)
+		info := procToInfo(proc, bindingMap)
 		result = append(result, info)
 	}
 	return result, nil
@@ -31,26 +32,39 @@ func (p *darwinProvider) FindByPID(pid int32) (*Info, error) {
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
-	info := procToInfo(proc, portMap)
+	bindingMap := buildBindingMap()
+	info := procToInfo(proc, bindingMap)
 	return &info, nil
 }
 
 func (p *darwinProvider) FindByPort(port uint32) ([]Info, error) {
-	portMap := buildPortMap()
-	procs, err := gopsProcess.Processes()
+	return p.FindBySocket(func(s Socket) bool {
+		return s.Port == port && s.Listening()
+	})
+}
+
+func (p *darwinProvider) FindBySocket(match func(Socket) bool) ([]Info, error) {
+	return findBySocket(match)
+}
+
+func (p *darwinProvider) FindByUnixSocket(_ string) ([]Info, error) {
+	return nil, ErrUnsupported
+}
+
+func (p *darwinProvider) FindByFile(_ string, _ bool) ([]Info, error) {
+	return nil, ErrUnsupported
+}
+
+func (p *darwinProvider) Environ(pid int32) ([]string, error) {
+	proc, err := gopsProcess.NewProcess(pid)
 	if err != nil {
 		return nil, err
 	}
-	var result []Info
-	for _, proc := range procs {
-		pid := proc.Pid
-		if portMap[pid] == port {
-			info := procToInfo(proc, portMap)
-			result = append(result, info)
-		}
-	}
-	return result, nil
+	return proc.Environ()
+}
+
+func (p *darwinProvider) SampleCPU(procs []Info, interval time.Duration) []Info {
+	return sampleCPU(procs, interval)
 }
 
 func (p *darwinProvider) Children(pid int32) ([]Info, error) {
@@ -62,10 +76,10 @@ func (p *darwinProvider) Children(pid int32) ([]Info, error) {
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
+	bindingMap := buildBindingMap()
 	result := make([]Info, 0, len(children))
 	for _, child := range children {
-		info := procToInfo(child, portMap)
+		info := procToInfo(child, bindingMap)
 		result = append(result, info)
 	}
 	return result, nil
@@ -83,6 +97,10 @@ func (p *darwinProvider) Signal(pid int32, sig Signal) error {
 	return syscall.Kill(int(pid), syscall.Signal(sig))
 }
 
+func (p *darwinProvider) Resume(pid int32) error {
+	return syscall.Kill(int(pid), syscall.SIGCONT)
+}
+
 func (p *darwinProvider) IsRunning(pid int32) bool {
 	proc, err := gopsProcess.NewProcess(pid)
 	if err != nil {
@@ -2,73 +2,86 @@ package process
 
 import (
 	"syscall"
+	"time"
 
 	gopsProcess "github.com/shirou/gopsutil/v4/process"
 )
 
-type linuxProvider struct{}
+type linuxProvider struct {
+	sockets     []inetSocket
+	socketsErr  error
+	socketsRead bool
+	allBindings map[int32][]Binding
+	children    map[int32][]int32
+}
 
 func New() Provider {
 	return &linuxProvider{}
 }
 
 func (p *linuxProvider) List() ([]Info, error) {
-	procs, err := gopsProcess.Processes()
+	pids, err := listPIDs()
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
-	result := make([]Info, 0, len(procs))
-	for _, proc := range procs {
-		info := procToInfo(proc, portMap)
-		result = append(result, info)
+	infos := buildInfos(pids, p.bindingsForAll(pids), nil)
+	children := make(map[int32][]int32)
+	for _, info := range infos {
+		if info.PPID > 0 {
+			children[info.PPID] = append(children[info.PPID], info.PID)
+		}
+	}
+	for i := range infos {
+		infos[i].Children = children[infos[i].PID]
+	}
+	if p.children == nil {
+		p.children = children
 	}
-	return result, nil
+	return infos, nil
 }
 
 func (p *linuxProvider) FindByPID(pid int32) (*Info, error) {
-	proc, err := gopsProcess.NewProcess(pid)
-	if err != nil {
+	if _, err := gopsProcess.NewProcess(pid); err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
-	info := procToInfo(proc, portMap)
-	return &info, nil
+	infos := p.infosForPIDs([]int32{pid})
+	if len(infos) == 0 {
+		return nil, ErrNotFound
+	}
+	return &infos[0], nil
 }
 
 func (p *linuxProvider) FindByPort(port uint32) ([]Info, error) {
-	portMap := buildPortMap()
-	procs, err := gopsProcess.Processes()
-	if err != nil {
-		return nil, err
-	}
-	var result []Info
-	for _, proc := range procs {
-		pid := proc.Pid
-		if portMap[pid] == port {
-			info := procToInfo(proc, portMap)
-			result = append(result, info)
-		}
-	}
-	return result, nil
+	return p.FindBySocket(func(s Socket) bool {
+		return s.Port == port && s.Listening()
+	})
+}
+
+func (p *linuxProvider) FindBySocket(match func(Socket) bool) ([]Info, error) {
+	return p.findBySocket(match)
+}
+
+func (p *linuxProvider) FindByUnixSocket(path string) ([]Info, error) {
+	return p.findByUnixSocket(path)
+}
+
+func (p *linuxProvider) FindByFile(path string, mount bool) ([]Info, error) {
+	return p.findByFile(path, mount)
+}
+
+func (p *linuxProvider) Environ(pid int32) ([]string, error) {
+	return readEnviron(pid)
+}
+
+func (p *linuxProvider) SampleCPU(procs []Info, interval time.Duration) []Info {
+	return sampleCPU(procs, interval)
 }
 
 func (p *linuxProvider) Children(pid int32) ([]Info, error) {
-	proc, err := gopsProcess.NewProcess(pid)
-	if err != nil {
-		return nil, err
-	}
-	children, err := proc.Children()
-	if err != nil {
+	if _, err := gopsProcess.NewProcess(pid); err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
-	result := make([]Info, 0, len(children))
-	for _, child := range children {
-		info := procToInfo(child, portMap)
-		result = append(result, info)
-	}
-	return result, nil
+	return p.infosForPIDs(p.childrenOf(pid)), nil
 }
 
 func (p *linuxProvider) Kill(pid int32) error {
@@ -83,6 +96,10 @@ func (p *linuxProvider) Signal(pid int32, sig Signal) error {
 	return syscall.Kill(int(pid), syscall.Signal(sig))
 }
 
+func (p *linuxProvider) Resume(pid int32) error {
+	return syscall.Kill(int(pid), syscall.SIGCONT)
+}
+
 func (p *linuxProvider) IsRunning(pid int32) bool {
 	proc, err := gopsProcess.NewProcess(pid)
 	if err != nil {
@@ -1,6 +1,9 @@
 package process
 
 import (
+	"fmt"
+	"time"
+
 	gopsProcess "github.com/shirou/gopsutil/v4/process"
 	"golang.org/x/sys/windows"
 )
@@ -16,10 +19,10 @@ func (p *windowsProvider) List() ([]Info, error) {
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
+	bindingMap := buildBindingMap()
 	result := make([]Info, 0, len(procs))
 	for _, proc := range procs {
-		info := procToInfo(proc, portMap)
+		info := procToInfo(proc, bindingMap)
 		result = append(result, info)
 	}
 	return result, nil
@@ -30,26 +33,39 @@ func (p *windowsProvider) FindByPID(pid int32) (*Info, error) {
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
-	info := procToInfo(proc, portMap)
+	bindingMap := buildBindingMap()
+	info := procToInfo(proc, bindingMap)
 	return &info, nil
 }
 
 func (p *windowsProvider) FindByPort(port uint32) ([]Info, error) {
-	portMap := buildPortMap()
-	procs, err := gopsProcess.Processes()
+	return p.FindBySocket(func(s Socket) bool {
+		return s.Port == port && s.Listening()
+	})
+}
+
+func (p *windowsProvider) FindBySocket(match func(Socket) bool) ([]Info, error) {
+	return findBySocket(match)
+}
+
+func (p *windowsProvider) FindByUnixSocket(_ string) ([]Info, error) {
+	return nil, ErrUnsupported
+}
+
+func (p *windowsProvider) FindByFile(_ string, _ bool) ([]Info, error) {
+	return nil, ErrUnsupported
+}
+
+func (p *windowsProvider) Environ(pid int32) ([]string, error) {
+	proc, err := gopsProcess.NewProcess(pid)
 	if err != nil {
 		return nil, err
 	}
-	var result []Info
-	for _, proc := range procs {
-		pid := proc.Pid
-		if portMap[pid] == port {
-			info := procToInfo(proc, portMap)
-			result = append(result, info)
-		}
-	}
-	return result, nil
+	return proc.Environ()
+}
+
+func (p *windowsProvider) SampleCPU(procs []Info, interval time.Duration) []Info {
+	return sampleCPU(procs, interval)
 }
 
 func (p *windowsProvider) Children(pid int32) ([]Info, error) {
@@ -61,10 +77,10 @@ func (p *windowsProvider) Children(pid int32) ([]Info, error) {
 	if err != nil {
 		return nil, err
 	}
-	portMap := buildPortMap()
+	bindingMap := buildBindingMap()
 	result := make([]Info, 0, len(children))
 	for _, child := range children {
-		info := procToInfo(child, portMap)
+		info := procToInfo(child, bindingMap)
 		result = append(result, info)
 	}
 	return result, nil
@@ -83,8 +99,19 @@ func (p *windowsProvider) Terminate(pid int32) error {
 	return p.Kill(pid)
 }
 
-func (p *windowsProvider) Signal(pid int32, _ Signal) error {
-	return p.Kill(pid)
+func (p *windowsProvider) Signal(pid int32, sig Signal) error {
+	switch sig {
+	case SignalKill:
+		return p.Kill(pid)
+	case SignalTerm:
+		return p.Terminate(pid)
+	default:
+		return fmt.Errorf("%w: %s%s", ErrUnsupported, sig.Name(), signalHint)
+	}
+}
+
+func (p *windowsProvider) Resume(_ int32) error {
+	return nil
 }
 
 func (p *windowsProvider) IsRunning(pid int32) bool {
@@ -0,0 +1,45 @@
+package process
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Signal
+		windows bool
+	}{
+		{name: "KILL", want: SignalKill, windows: true},
+		{name: "sigterm", want: SignalTerm, windows: true},
+		{name: " SIGTERM ", want: SignalTerm, windows: true},
+		{name: "HUP", want: SignalHup},
+		{name: "int", want: SignalInt},
+		{name: "1", want: SignalHup},
+		{name: "BOGUS"},
+		{name: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := ParseSignal(tt.name)
+			supported := tt.want != 0 && (runtime.GOOS != "windows" || tt.windows)
+			if !supported {
+				if err == nil {
+					t.Fatalf("ParseSignal(%q) = %v, want error", tt.name, sig)
+				}
+				if runtime.GOOS == "windows" && !strings.Contains(err.Error(), "KILL and TERM") {
+					t.Fatalf("ParseSignal(%q) error = %q, want a hint about KILL and TERM", tt.name, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSignal(%q): %v", tt.name, err)
+			}
+			if sig != tt.want {
+				t.Fatalf("ParseSignal(%q) = %v, want %v", tt.name, sig, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package process
+
+import "syscall"
+
+var signals = map[string]Signal{
+	"HUP":  Signal(syscall.SIGHUP),
+	"INT":  Signal(syscall.SIGINT),
+	"QUIT": Signal(syscall.SIGQUIT),
+	"KILL": Signal(syscall.SIGKILL),
+	"USR1": Signal(syscall.SIGUSR1),
+	"USR2": Signal(syscall.SIGUSR2),
+	"TERM": Signal(syscall.SIGTERM),
+	"CONT": Signal(syscall.SIGCONT),
+	"STOP": Signal(syscall.SIGSTOP),
+}
+
+const (
+	numericSignals = true
+	signalHint     = ""
+)
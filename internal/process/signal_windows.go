@@ -0,0 +1,13 @@
+package process
+
+import "syscall"
+
+var signals = map[string]Signal{
+	"KILL": Signal(syscall.SIGKILL),
+	"TERM": Signal(syscall.SIGTERM),
+}
+
+const (
+	numericSignals = false
+	signalHint     = " — Windows only supports KILL and TERM"
+)
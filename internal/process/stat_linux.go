@@ -0,0 +1,101 @@
+package process
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const pfKthread = 0x00200000
+
+type procStat struct {
+	ppid         int32
+	pgid         int32
+	sid          int32
+	ttyNr        uint64
+	kernelThread bool
+}
+
+func readStat(pid int32) (procStat, error) {
+	data, err := os.ReadFile(procPath(pid, "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+	return parseStat(string(data))
+}
+
+func parseStat(data string) (procStat, error) {
+	end := strings.LastIndexByte(data, ')')
+	if end < 0 {
+		return procStat{}, fmt.Errorf("malformed stat line")
+	}
+	fields := strings.Fields(data[end+1:])
+	if len(fields) < 5 {
+		return procStat{}, fmt.Errorf("malformed stat line")
+	}
+
+	ppid, _ := strconv.ParseInt(fields[1], 10, 32)
+	pgid, _ := strconv.ParseInt(fields[2], 10, 32)
+	sid, _ := strconv.ParseInt(fields[3], 10, 32)
+	ttyNr, _ := strconv.ParseUint(fields[4], 10, 64)
+	var flags uint64
+	if len(fields) > 6 {
+		flags, _ = strconv.ParseUint(fields[6], 10, 64)
+	}
+	return procStat{
+		ppid:         int32(ppid),
+		pgid:         int32(pgid),
+		sid:          int32(sid),
+		ttyNr:        ttyNr,
+		kernelThread: flags&pfKthread != 0,
+	}, nil
+}
+
+func childPIDs() map[int32][]int32 {
+	pids, err := listPIDs()
+	if err != nil {
+		return nil
+	}
+	children := make(map[int32][]int32)
+	for _, pid := range pids {
+		st, err := readStat(pid)
+		if err != nil || st.ppid <= 0 {
+			continue
+		}
+		children[st.ppid] = append(children[st.ppid], pid)
+	}
+	return children
+}
+
+func ttyName(ttyNr uint64) string {
+	if ttyNr == 0 {
+		return ""
+	}
+	major := (ttyNr >> 8) & 0xfff
+	minor := (ttyNr & 0xff) | ((ttyNr >> 12) & 0xfff00)
+	switch {
+	case major >= 136 && major <= 143:
+		return fmt.Sprintf("pts/%d", (major-136)*256+minor)
+	case major == 4 && minor < 64:
+		return fmt.Sprintf("tty%d", minor)
+	case major == 4:
+		return fmt.Sprintf("ttyS%d", minor-64)
+	case major == 5 && minor == 1:
+		return "console"
+	default:
+		return fmt.Sprintf("%d:%d", major, minor)
+	}
+}
+
+func readWChan(pid int32) string {
+	data, err := os.ReadFile(procPath(pid, "wchan"))
+	if err != nil {
+		return ""
+	}
+	wchan := strings.TrimSpace(string(data))
+	if wchan == "0" {
+		return ""
+	}
+	return wchan
+}
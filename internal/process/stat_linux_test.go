@@ -0,0 +1,77 @@
+package process
+
+import "testing"
+
+func TestParseStat(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want procStat
+	}{
+		{
+			name: "process",
+			line: "4242 (bash) S 4200 4242 4242 34816 4300 4194304 0 0 0 0 0 0 0 0 20 0 1 0 100",
+			want: procStat{ppid: 4200, pgid: 4242, sid: 4242, ttyNr: 34816},
+		},
+		{
+			name: "kernel thread",
+			line: "2 (kthreadd) S 0 0 0 0 -1 2129984 0 0 0 0 0 0 0 0 20 0 1 0 6",
+			want: procStat{kernelThread: true},
+		},
+		{
+			name: "kernel worker",
+			line: "50 (kworker/0:1-events) I 2 0 0 0 -1 69238880 0 0 0 0 0 0 0 0 20 0 1 0 9",
+			want: procStat{ppid: 2, kernelThread: true},
+		},
+		{
+			name: "name with spaces and parentheses",
+			line: "77 (tmux: server (1)) S 1 77 77 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 50",
+			want: procStat{ppid: 1, pgid: 77, sid: 77},
+		},
+		{
+			name: "short line",
+			line: "77 (tmux) S 1 77 77 0",
+			want: procStat{ppid: 1, pgid: 77, sid: 77},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStat(tt.line)
+			if err != nil {
+				t.Fatalf("parseStat(%q): %v", tt.line, err)
+			}
+			if got != tt.want {
+				t.Fatalf("parseStat(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStatErrors(t *testing.T) {
+	for _, line := range []string{"", "4242 bash S 1 2 3 4", "4242 (bash) S 1 2"} {
+		if _, err := parseStat(line); err == nil {
+			t.Errorf("parseStat(%q) succeeded, want error", line)
+		}
+	}
+}
+
+func TestTTYName(t *testing.T) {
+	tests := []struct {
+		ttyNr uint64
+		want  string
+	}{
+		{0, ""},
+		{136<<8 | 3, "pts/3"},
+		{1<<20 | 136<<8 | 44, "pts/300"},
+		{137<<8 | 2, "pts/258"},
+		{4<<8 | 1, "tty1"},
+		{4<<8 | 64, "ttyS0"},
+		{5<<8 | 1, "console"},
+		{204<<8 | 64, "204:64"},
+	}
+	for _, tt := range tests {
+		if got := ttyName(tt.ttyNr); got != tt.want {
+			t.Errorf("ttyName(%d) = %q, want %q", tt.ttyNr, got, tt.want)
+		}
+	}
+}
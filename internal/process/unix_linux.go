@@ -0,0 +1,60 @@
+package process
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (p *linuxProvider) findByUnixSocket(path string) ([]Info, error) {
+	inodes, err := unixSocketInodes(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(inodes) == 0 {
+		return nil, nil
+	}
+	pids, err := pidsHoldingSockets(inodes)
+	if err != nil {
+		return nil, err
+	}
+	return p.infosForPIDs(pids), nil
+}
+
+func unixSocketInodes(path string) (map[uint64]bool, error) {
+	f, err := os.Open(filepath.Join(procRoot, "net", "unix"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	abstract := strings.HasPrefix(path, "@")
+	if !abstract {
+		path = filepath.Clean(path)
+	}
+
+	inodes := make(map[uint64]bool)
+	scanner := bufio.NewScanner(f)
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		sockPath := strings.Join(fields[7:], " ")
+		if !abstract {
+			sockPath = filepath.Clean(sockPath)
+		}
+		if sockPath != path {
+			continue
+		}
+		inode, err := strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			continue
+		}
+		inodes[inode] = true
+	}
+	return inodes, scanner.Err()
+}
@@ -330,6 +330,18 @@ func formatEditable(cfg *config.Config, f *config.Field) string {
 			parts = append(parts, k+"="+m[k])
 		}
 		return strings.Join(parts, ", ")
+	case config.AliasMap:
+		m := val.(map[string]config.Alias)
+		names := make([]string, 0, len(m))
+		for k := range m {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(m))
+		for _, k := range names {
+			parts = append(parts, k+"="+strings.Join(m[k].Queries, " "))
+		}
+		return strings.Join(parts, ", ")
 	default:
 		return fmt.Sprintf("%v", val)
 	}
@@ -364,6 +376,32 @@ func parseAndSet(cfg *config.Config, f *config.Field, raw string) error {
 			}
 		}
 		return config.SetValue(cfg, f.Key, m)
+	case config.AliasMap:
+		val, _ := config.GetValue(cfg, f.Key)
+		existing := val.(map[string]config.Alias)
+		m := make(map[string]config.Alias)
+		if strings.TrimSpace(raw) != "" {
+			parts := strings.Split(raw, ",")
+			for _, p := range parts {
+				p = strings.TrimSpace(p)
+				if p == "" {
+					continue
+				}
+				kv := strings.SplitN(p, "=", 2)
+				queries := []string{}
+				if len(kv) == 2 {
+					queries = strings.Fields(kv[1])
+				}
+				if len(queries) == 0 {
+					return fmt.Errorf("invalid format %q, use name=query [query...]", p)
+				}
+				name := strings.TrimSpace(kv[0])
+				alias := existing[name]
+				alias.Queries = queries
+				m[name] = alias
+			}
+		}
+		return config.SetValue(cfg, f.Key, m)
 	default:
 		val, err := config.ParseValue(f, raw)
 		if err != nil {
@@ -1,6 +1,11 @@
 package ui
 
-import "github.com/charmbracelet/huh"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
 
 func Confirm(message string) (bool, error) {
 	var confirmed bool
@@ -19,3 +24,22 @@ func Confirm(message string) (bool, error) {
 	}
 	return confirmed, nil
 }
+
+func ConfirmStopUnits(units []string) (bool, error) {
+	var confirmed bool
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Managed by systemd: %s", strings.Join(units, ", "))).
+				Description("systemd may restart these processes. Stop the unit(s) instead of signaling?").
+				Affirmative("Stop unit").
+				Negative("Signal anyway").
+				Value(&confirmed),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return false, err
+	}
+	return confirmed, nil
+}
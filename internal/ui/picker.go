@@ -11,8 +11,8 @@ func PickProcesses(procs []process.Info) ([]process.Info, error) {
 	options := make([]huh.Option[int], 0, len(procs))
 	for i, p := range procs {
 		label := fmt.Sprintf("%-8d %-20s %-15s", p.PID, p.Name, p.User)
-		if p.Port > 0 {
-			label += fmt.Sprintf(" :%d", p.Port)
+		if len(p.Bindings) > 0 {
+			label += " " + formatBindings(p.Bindings)
 		}
 		options = append(options, huh.NewOption(label, i))
 	}
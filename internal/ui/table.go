@@ -2,35 +2,81 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aiomayo/hdf/internal/process"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
 )
 
-func RenderTable(procs []process.Info, verbose bool) string {
+var optionalColumns = map[string]struct {
+	header string
+	value  func(process.Info) string
+}{
+	"tty":   {"TTY", func(p process.Info) string { return p.TTY }},
+	"sid":   {"SID", func(p process.Info) string { return formatID(p.SID) }},
+	"pgid":  {"PGID", func(p process.Info) string { return formatID(p.PGID) }},
+	"state": {"State", func(p process.Info) string { return p.State }},
+	"wchan": {"WChan", func(p process.Info) string { return p.WChan }},
+}
+
+func Columns() []string {
+	names := make([]string, 0, len(optionalColumns))
+	for name := range optionalColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func IsColumn(name string) bool {
+	_, ok := optionalColumns[name]
+	return ok
+}
+
+func RenderTable(procs []process.Info, verbose bool, columns ...string) string {
 	headers := []string{"PID", "Name", "User", "Port"}
+	showAccess := hasAccess(procs)
+	if showAccess {
+		headers = append(headers, "Access")
+	}
+	showContainer := hasContainer(procs)
+	if showContainer {
+		headers = append(headers, "Container")
+	}
+	for _, c := range columns {
+		headers = append(headers, optionalColumns[c].header)
+	}
 	if verbose {
-		headers = append(headers, "CPU%", "MEM", "Cmdline")
+		headers = append(headers, "CPU%", "MEM", "Age", "Cmdline")
 	}
 
+	ports := portColumn(procs)
 	rows := make([][]string, 0, len(procs))
-	for _, p := range procs {
-		port := ""
-		if p.Port > 0 {
-			port = fmt.Sprintf("%d", p.Port)
-		}
+	for i, p := range procs {
 		row := []string{
 			fmt.Sprintf("%d", p.PID),
 			p.Name,
 			p.User,
-			port,
+			ports[i],
+		}
+		if showAccess {
+			row = append(row, strings.Join(p.Access, ","))
+		}
+		if showContainer {
+			row = append(row, formatContainer(p))
+		}
+		for _, c := range columns {
+			row = append(row, optionalColumns[c].value(p))
 		}
 		if verbose {
 			row = append(row,
 				fmt.Sprintf("%.1f", p.CPUPercent),
 				formatBytes(p.MemRSS),
+				formatAge(p.CreateTime),
 				truncate(p.Cmdline, 60),
 			)
 		}
@@ -55,6 +101,72 @@ func RenderTable(procs []process.Info, verbose bool) string {
 	return t.Render()
 }
 
+func hasAccess(procs []process.Info) bool {
+	for _, p := range procs {
+		if len(p.Access) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func hasContainer(procs []process.Info) bool {
+	for _, p := range procs {
+		if p.ContainerID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func formatContainer(p process.Info) string {
+	if p.ContainerID == "" {
+		return ""
+	}
+	id := p.ContainerID
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return p.ContainerRuntime + ":" + id
+}
+
+func portColumn(procs []process.Info) []string {
+	cells := make([]string, len(procs))
+	grouped := false
+	for i, p := range procs {
+		cells[i] = formatBindings(p.Bindings)
+		if cells[i] != "" && cells[i] != cells[0] {
+			grouped = true
+		}
+	}
+	if !grouped {
+		return cells
+	}
+	for i := len(cells) - 1; i > 0; i-- {
+		if cells[i] == cells[i-1] {
+			cells[i] = ""
+		}
+	}
+	return cells
+}
+
+func formatBindings(bindings []process.Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if s := b.String(); !slices.Contains(parts, s) {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatID(id int32) string {
+	if id <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", id)
+}
+
 func formatBytes(b uint64) string {
 	const (
 		kb = 1024
@@ -73,6 +185,23 @@ func formatBytes(b uint64) string {
 	}
 }
 
+func formatAge(created time.Time) string {
+	if created.IsZero() {
+		return ""
+	}
+	d := time.Since(created)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
+
 func truncate(s string, maxLen int) string {
 	s = strings.ReplaceAll(s, "\n", " ")
 	if len(s) > maxLen {
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/aiomayo/hdf/internal/process"
+)
+
+func TestPortColumn(t *testing.T) {
+	tcp := func(addr string, port uint32) process.Binding {
+		return process.Binding{Proto: process.ProtoTCP, Addr: addr, Port: port}
+	}
+	tests := []struct {
+		name  string
+		procs []process.Info
+		want  []string
+	}{
+		{
+			name: "single port repeats",
+			procs: []process.Info{
+				{PID: 1, Bindings: []process.Binding{tcp("0.0.0.0", 80), tcp("::", 80)}},
+				{PID: 2, Bindings: []process.Binding{tcp("0.0.0.0", 80)}},
+			},
+			want: []string{"80", "80"},
+		},
+		{
+			name: "range is grouped per port",
+			procs: []process.Info{
+				{PID: 1, Bindings: []process.Binding{tcp("0.0.0.0", 3000)}},
+				{PID: 2, Bindings: []process.Binding{tcp("0.0.0.0", 3000)}},
+				{PID: 3, Bindings: []process.Binding{tcp("127.0.0.1", 3005), {Proto: process.ProtoUDP, Addr: "0.0.0.0", Port: 3006}}},
+				{PID: 4, Bindings: []process.Binding{tcp("127.0.0.1", 3005), {Proto: process.ProtoUDP, Addr: "0.0.0.0", Port: 3006}}},
+				{PID: 5},
+			},
+			want: []string{"3000", "", "127.0.0.1:3005,udp:3006", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := portColumn(tt.procs); !slices.Equal(got, tt.want) {
+				t.Fatalf("portColumn = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}